@@ -0,0 +1,503 @@
+package authconfig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/keys"
+	"github.com/nookcoder/go-boilerplate/auth/paseto"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+// DefaultAccessTTL: ttl.access 를 설정하지 않은 경우의 access token 유효 기간
+const DefaultAccessTTL = 15 * time.Minute
+
+// Auth: 설정으로 만든 토큰 발급, 검증 구성요소
+// Config, Creator, Manager, Validator, Middleware 는 JWT 전용으로 PASETO 구성이면 nil
+// algorithm 을 바꿀 수 있는 서비스는 Token* 필드만 사용
+type Auth struct {
+	Config *v4jwt.Config
+	// Creator, Manager: 공개키만 설정한 검증 전용 구성이면 nil
+	Creator    *v4jwt.Creator
+	Manager    v4jwt.Manager[jwt.Claims]
+	Validator  *v4jwt.Validator[jwt.Claims]
+	Middleware *v4jwt.JwtMiddleware
+
+	// Token*: 토큰 형식 (JWT, PASETO) 에 관계없이 사용하는 구성요소
+	// 설정의 algorithm 만 바꿔서 토큰 형식을 전환할 수 있음
+	// TokenCreator, TokenManager: 검증 전용 구성이면 nil
+	TokenCreator    token.Creator
+	TokenManager    token.Manager[token.Claims]
+	TokenValidator  token.Validator[token.Claims]
+	TokenMiddleware *token.Middleware
+
+	Issuer     string
+	Audience   string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+type buildOptions struct {
+	claims            jwt.Claims
+	tokenClaims       token.Claims
+	configOptions     []v4jwt.ConfigOption
+	validatorOptions  []v4jwt.ValidatorOption
+	middlewareOptions []v4jwt.MiddlewareOption
+	lookupEnv         func(string) (string, bool)
+}
+
+type BuildOption func(*buildOptions)
+
+// jwtOnly: JWT 구성요소 (Config, Manager, Middleware 등) 에만 적용되는 옵션을 사용했는지 여부
+func (o buildOptions) jwtOnly() bool {
+	return o.claims != nil || len(o.configOptions) > 0 || len(o.validatorOptions) > 0 || len(o.middlewareOptions) > 0
+}
+
+// WithClaims: 미들웨어가 요청마다 복제해서 사용할 클레임 타입, 기본값은 *jwt.RegisteredClaims
+func WithClaims(claims jwt.Claims) BuildOption {
+	return func(o *buildOptions) {
+		o.claims = claims
+	}
+}
+
+// WithTokenClaims: TokenMiddleware 가 요청마다 복제해서 사용할 클레임 타입, 기본값은 *token.RegisteredClaims
+func WithTokenClaims(claims token.Claims) BuildOption {
+	return func(o *buildOptions) {
+		o.tokenClaims = claims
+	}
+}
+
+// WithConfigOptions: 설정 파일로 표현하지 않는 v4jwt.Config 옵션 (WithMetrics, WithTracer 등)
+func WithConfigOptions(opts ...v4jwt.ConfigOption) BuildOption {
+	return func(o *buildOptions) {
+		o.configOptions = append(o.configOptions, opts...)
+	}
+}
+
+// WithValidatorOptions: 추가 Validator 옵션 (WithReplayCache 등)
+func WithValidatorOptions(opts ...v4jwt.ValidatorOption) BuildOption {
+	return func(o *buildOptions) {
+		o.validatorOptions = append(o.validatorOptions, opts...)
+	}
+}
+
+// WithMiddlewareOptions: 추가 미들웨어 옵션 (WithDPoP 등)
+func WithMiddlewareOptions(opts ...v4jwt.MiddlewareOption) BuildOption {
+	return func(o *buildOptions) {
+		o.middlewareOptions = append(o.middlewareOptions, opts...)
+	}
+}
+
+// WithSecretLookupEnv: key.secret_env 를 읽을 환경변수 조회 함수, 기본값은 os.LookupEnv
+// Parse 에 WithLookupEnv 를 사용했다면 같은 함수를 전달
+func WithSecretLookupEnv(lookup func(string) (string, bool)) BuildOption {
+	return func(o *buildOptions) {
+		o.lookupEnv = lookup
+	}
+}
+
+// Build: 설정을 검증하고 키를 읽어 Creator, Validator, Manager, 미들웨어 생성
+// 키 소스의 에러도 해당 필드의 FieldError 로 반환
+// PASETO 구성에 JWT 전용 옵션 (WithClaims, WithConfigOptions 등) 을 사용하면
+// nil 인 JWT 구성요소를 사용하는 대신 algorithm 의 FieldError 로 반환
+func Build(c *Config, opts ...BuildOption) (*Auth, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	o := buildOptions{tokenClaims: &token.RegisteredClaims{}, lookupEnv: os.LookupEnv}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if isPASETO(c.Algorithm) {
+		if o.jwtOnly() {
+			return nil, fieldError("algorithm", "WithClaims, WithConfigOptions, WithValidatorOptions and WithMiddlewareOptions are not supported with %s, use the Token* fields", c.Algorithm)
+		}
+		return buildPASETO(c, o)
+	}
+	if o.claims == nil {
+		o.claims = &jwt.RegisteredClaims{}
+	}
+
+	method := jwt.GetSigningMethod(c.Algorithm)
+	configOptions := append([]v4jwt.ConfigOption{v4jwt.WithKeyID(c.Key.ID)}, o.configOptions...)
+
+	var config *v4jwt.Config
+	var canSign bool
+	if isHMAC(method) {
+		secret, err := c.Key.secret(o.lookupEnv)
+		if err != nil {
+			return nil, err
+		}
+		config = v4jwt.NewConfig(method, secret, configOptions...)
+		canSign = true
+	} else {
+		signingKey, verifyKey, err := c.Key.keyPair(method)
+		if err != nil {
+			return nil, err
+		}
+		config = v4jwt.NewKeyPairConfig(method, signingKey, verifyKey, configOptions...)
+		canSign = signingKey != nil
+	}
+
+	validatorOptions := []v4jwt.ValidatorOption{}
+	if c.Issuer != "" {
+		validatorOptions = append(validatorOptions, v4jwt.WithIssuer(c.Issuer))
+	}
+	if c.Audience != "" {
+		validatorOptions = append(validatorOptions, v4jwt.WithAudience(c.Audience))
+	}
+	if len(c.TokenTypes) > 0 {
+		validatorOptions = append(validatorOptions, v4jwt.WithExpectedTypes(c.TokenTypes...))
+	}
+	if c.CacheSize > 0 {
+		validatorOptions = append(validatorOptions, v4jwt.WithTokenCache(c.CacheSize))
+	}
+	validator := v4jwt.NewValidator[jwt.Claims](config, append(validatorOptions, o.validatorOptions...)...)
+
+	a := newAuth(c)
+	a.Config = config
+	a.Validator = validator
+	a.TokenValidator = v4jwt.AdaptValidator[token.Claims](validator)
+	if canSign {
+		var creatorOptions []v4jwt.CreatorOption
+		if len(c.TokenTypes) > 0 {
+			creatorOptions = append(creatorOptions, v4jwt.WithTokenType(c.TokenTypes[0]))
+		}
+		a.Creator = v4jwt.NewCreator(config, creatorOptions...)
+		a.Manager = v4jwt.NewTokenManager[jwt.Claims](a.Creator, validator)
+		a.TokenCreator = v4jwt.AdaptCreator(a.Creator)
+		a.TokenManager = token.NewManager(a.TokenCreator, a.TokenValidator)
+	}
+
+	middlewareOptions := []v4jwt.MiddlewareOption{}
+	if len(c.Exclude) > 0 {
+		middlewareOptions = append(middlewareOptions, v4jwt.WithExclusions(exclusions(c.Exclude)...))
+	}
+	a.Middleware = v4jwt.NewJwtMiddleware(
+		extractor(c.Extractors),
+		validator,
+		errorHandler(c.ErrorFormat),
+		o.claims,
+		append(middlewareOptions, o.middlewareOptions...)...,
+	)
+	a.TokenMiddleware = tokenMiddleware(c, a.TokenValidator, o.tokenClaims)
+	return a, nil
+}
+
+// buildPASETO: v4.local 은 secret, v4.public 은 Ed25519 키 파일로 PASETO 구성요소 생성
+func buildPASETO(c *Config, o buildOptions) (*Auth, error) {
+	configOptions := []paseto.ConfigOption{paseto.WithKeyID(c.Key.ID)}
+
+	var config *paseto.Config
+	var canSign bool
+	if c.Algorithm == paseto.Local {
+		key, err := c.Key.localKey(o.lookupEnv)
+		if err != nil {
+			return nil, err
+		}
+		config = paseto.NewLocalConfig(key, configOptions...)
+		canSign = true
+	} else {
+		// v4.public 은 EdDSA 와 같은 Ed25519 키 사용
+		signer, public, err := c.Key.keyPair(jwt.SigningMethodEdDSA)
+		if err != nil {
+			return nil, err
+		}
+		var signingKey ed25519.PrivateKey
+		if signer != nil {
+			signingKey = signer.(ed25519.PrivateKey)
+		}
+		config = paseto.NewPublicConfig(signingKey, public.(ed25519.PublicKey), configOptions...)
+		canSign = signer != nil
+	}
+
+	validatorOptions := []paseto.ValidatorOption{}
+	if c.Issuer != "" {
+		validatorOptions = append(validatorOptions, paseto.WithIssuer(c.Issuer))
+	}
+	if c.Audience != "" {
+		validatorOptions = append(validatorOptions, paseto.WithAudience(c.Audience))
+	}
+	if len(c.TokenTypes) > 0 {
+		validatorOptions = append(validatorOptions, paseto.WithExpectedTypes(c.TokenTypes...))
+	}
+
+	a := newAuth(c)
+	a.TokenValidator = paseto.NewValidator[token.Claims](config, validatorOptions...)
+	if canSign {
+		var creatorOptions []paseto.CreatorOption
+		if len(c.TokenTypes) > 0 {
+			creatorOptions = append(creatorOptions, paseto.WithTokenType(c.TokenTypes[0]))
+		}
+		a.TokenCreator = paseto.NewCreator(config, creatorOptions...)
+		a.TokenManager = token.NewManager(a.TokenCreator, a.TokenValidator)
+	}
+	a.TokenMiddleware = tokenMiddleware(c, a.TokenValidator, o.tokenClaims)
+	return a, nil
+}
+
+// newAuth: 토큰 형식과 관계없는 설정 값
+func newAuth(c *Config) *Auth {
+	a := &Auth{
+		Issuer:     c.Issuer,
+		Audience:   c.Audience,
+		AccessTTL:  time.Duration(c.TTL.Access),
+		RefreshTTL: time.Duration(c.TTL.Refresh),
+	}
+	if a.AccessTTL == 0 {
+		a.AccessTTL = DefaultAccessTTL
+	}
+	return a
+}
+
+// tokenMiddleware: token 패키지의 에러 핸들러를 사용하는 미들웨어
+func tokenMiddleware(c *Config, validator token.Validator[token.Claims], claims token.Claims) *token.Middleware {
+	var opts []token.MiddlewareOption
+	if len(c.Exclude) > 0 {
+		opts = append(opts, token.WithExclusions(exclusions(c.Exclude)...))
+	}
+	handler := token.DefaultErrorHandler
+	if c.ErrorFormat == ErrorFormatProblem {
+		handler = token.ProblemErrorHandler
+	}
+	return token.NewMiddleware(extractor(c.Extractors), validator, handler, claims, opts...)
+}
+
+// Claims: iss, aud, iat, exp (AccessTTL), jti 를 채운 access token 클레임
+func (a *Auth) Claims(subject string) (*jwt.RegisteredClaims, error) {
+	id, err := v4jwt.NewTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := jwt.TimeFunc()
+	claims := &jwt.RegisteredClaims{
+		Issuer:    a.Issuer,
+		Subject:   subject,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(a.AccessTTL)),
+		ID:        id,
+	}
+	if a.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{a.Audience}
+	}
+	return claims, nil
+}
+
+// TokenClaims: TokenCreator 로 발급할 Claims 와 같은 값의 token.RegisteredClaims
+func (a *Auth) TokenClaims(subject string) (*token.RegisteredClaims, error) {
+	claims, err := a.Claims(subject)
+	if err != nil {
+		return nil, err
+	}
+	return &token.RegisteredClaims{
+		Issuer:    claims.Issuer,
+		Subject:   claims.Subject,
+		Audience:  token.ClaimStrings(claims.Audience),
+		ExpiresAt: token.NewNumericDate(claims.ExpiresAt.Time),
+		IssuedAt:  token.NewNumericDate(claims.IssuedAt.Time),
+		ID:        claims.ID,
+	}, nil
+}
+
+// secret: HMAC secret
+func (k KeyConfig) secret(lookup func(string) (string, bool)) ([]byte, error) {
+	field, secret, err := k.readSecret(lookup)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) < keys.MinSecretSize {
+		return nil, fieldError(field, "%w: HMAC secret must be at least %d bytes", keys.ErrKeyTooShort, keys.MinSecretSize)
+	}
+	return secret, nil
+}
+
+// localKey: v4.local 키, 32 바이트 또는 hex 로 인코딩한 32 바이트
+func (k KeyConfig) localKey(lookup func(string) (string, bool)) ([]byte, error) {
+	field, secret, err := k.readSecret(lookup)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) == 2*paseto.LocalKeySize {
+		if key, err := hex.DecodeString(string(secret)); err == nil {
+			return key, nil
+		}
+	}
+	if len(secret) != paseto.LocalKeySize {
+		return nil, fieldError(field, "%s key must be %d bytes or %d hex characters", paseto.Local, paseto.LocalKeySize, 2*paseto.LocalKeySize)
+	}
+	return secret, nil
+}
+
+// readSecret: secret, secret_env, secret_file 중 설정된 필드와 값
+func (k KeyConfig) readSecret(lookup func(string) (string, bool)) (string, []byte, error) {
+	var field string
+	var secret []byte
+	switch {
+	case k.Secret != "":
+		field, secret = "key.secret", []byte(k.Secret)
+	case k.SecretEnv != "":
+		field = "key.secret_env"
+		value, ok := lookup(k.SecretEnv)
+		if !ok {
+			return "", nil, fieldError(field, "environment variable %s is not set", k.SecretEnv)
+		}
+		secret = []byte(value)
+	default:
+		field = "key.secret_file"
+		b, err := os.ReadFile(k.SecretFile)
+		if err != nil {
+			return "", nil, &FieldError{Field: field, Err: err}
+		}
+		secret = bytes.TrimRight(b, "\r\n")
+	}
+	return field, secret, nil
+}
+
+// keyPair: 개인키 파일과 공개키 파일, 공개키 파일이 없으면 개인키의 공개키 사용
+func (k KeyConfig) keyPair(method jwt.SigningMethod) (crypto.Signer, crypto.PublicKey, error) {
+	var signer crypto.Signer
+	var public crypto.PublicKey
+
+	if k.PrivateKeyFile != "" {
+		key, err := readKey(k.PrivateKeyFile)
+		if err != nil {
+			return nil, nil, &FieldError{Field: "key.private_key_file", Err: err}
+		}
+		var ok bool
+		if signer, ok = key.(crypto.Signer); !ok {
+			return nil, nil, fieldError("key.private_key_file", "%s does not contain a private key", k.PrivateKeyFile)
+		}
+		if err := checkKeyType(method, signer.Public()); err != nil {
+			return nil, nil, &FieldError{Field: "key.private_key_file", Err: err}
+		}
+		public = signer.Public()
+	}
+
+	if k.PublicKeyFile != "" {
+		key, err := readKey(k.PublicKeyFile)
+		if err != nil {
+			return nil, nil, &FieldError{Field: "key.public_key_file", Err: err}
+		}
+		if public, err = keys.Public(key); err != nil {
+			return nil, nil, &FieldError{Field: "key.public_key_file", Err: err}
+		}
+		if err := checkKeyType(method, public); err != nil {
+			return nil, nil, &FieldError{Field: "key.public_key_file", Err: err}
+		}
+		if signer != nil && !signer.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(public) {
+			return nil, nil, fieldError("key.public_key_file", "does not match key.private_key_file")
+		}
+	}
+
+	return signer, public, nil
+}
+
+// readKey: PEM 또는 JWK 키 파일
+func readKey(path string) (interface{}, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return keys.ParseKey(b)
+}
+
+// checkKeyType: 알고리즘과 키 종류, ECDSA 곡선이 일치하는지 확인
+func checkKeyType(method jwt.SigningMethod, public crypto.PublicKey) error {
+	alg := method.Alg()
+	var ok bool
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		_, ok = public.(*rsa.PublicKey)
+	case *jwt.SigningMethodECDSA:
+		var key *ecdsa.PublicKey
+		if key, ok = public.(*ecdsa.PublicKey); ok {
+			ok = keys.Alg(key) == alg
+		}
+	case *jwt.SigningMethodEd25519:
+		_, ok = public.(ed25519.PublicKey)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %T cannot be used with %s", keys.ErrUnsupportedKey, public, alg)
+	}
+	return nil
+}
+
+func extractor(configs []ExtractorConfig) v4jwt.Extractor {
+	if len(configs) == 0 {
+		return v4jwt.AuthHeaderExtractor
+	}
+
+	extractors := make([]v4jwt.Extractor, 0, len(configs))
+	for _, c := range configs {
+		switch c.Type {
+		case ExtractorHeader:
+			extractors = append(extractors, v4jwt.AuthHeaderExtractor)
+		case ExtractorCookie:
+			extractors = append(extractors, v4jwt.CookieExtractor(c.Name))
+		case ExtractorDPoP:
+			extractors = append(extractors, v4jwt.DPoPHeaderExtractor)
+		}
+	}
+	if len(extractors) == 1 {
+		return extractors[0]
+	}
+	return v4jwt.MultiExtractor(extractors...)
+}
+
+// exclusions: 정리한 경로로 비교하여 /public/../private 처럼 제외 prefix 를 우회하는 요청은 검증
+func exclusions(configs []ExclusionConfig) []v4jwt.RequestMatcher {
+	matchers := make([]v4jwt.RequestMatcher, 0, len(configs))
+	for _, c := range configs {
+		c := c
+		matchers = append(matchers, func(r *http.Request) bool {
+			if len(c.Methods) > 0 && !containsMethod(c.Methods, r.Method) {
+				return false
+			}
+			p := cleanPath(r.URL.Path)
+			if c.Path != "" {
+				return p == c.Path
+			}
+			return strings.HasPrefix(p, c.Prefix)
+		})
+	}
+	return matchers
+}
+
+// cleanPath: . 과 .. , 중복된 / 를 정리한 경로, 끝의 / 는 유지
+func cleanPath(p string) string {
+	cleaned := path.Clean("/" + p)
+	if strings.HasSuffix(p, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func errorHandler(format string) v4jwt.ErrorHandler {
+	if format == ErrorFormatProblem {
+		return v4jwt.ProblemErrorHandler
+	}
+	return v4jwt.DefaultErrorHandler
+}
@@ -0,0 +1,298 @@
+package authconfig
+
+import (
+	"crypto"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/keys"
+	"github.com/nookcoder/go-boilerplate/auth/paseto"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	c, err := Parse([]byte(testYAML), WithLookupEnv(noEnv))
+	require.NoError(t, err)
+
+	// 프로세스 환경변수가 아닌 조회 함수로 key.secret_env 를 읽음
+	lookup := func(key string) (string, bool) {
+		if key == "TEST_JWT_SECRET" {
+			return "0123456789abcdef0123456789abcdef", true
+		}
+		return "", false
+	}
+	_, err = Build(c)
+	assert.ErrorContains(t, err, "key.secret_env")
+	auth, err := Build(c, WithSecretLookupEnv(lookup))
+	require.NoError(t, err)
+	require.NotNil(t, auth.Manager)
+	assert.Equal(t, 10*time.Minute, auth.AccessTTL)
+	assert.Equal(t, 720*time.Hour, auth.RefreshTTL)
+
+	claims, err := auth.Claims("user-1")
+	require.NoError(t, err)
+	token, err := auth.Manager.CreateToken(claims)
+	require.NoError(t, err)
+
+	header := peekHeader(t, token)
+	assert.Equal(t, "at+jwt", header["typ"])
+	assert.Equal(t, "key-1", header["kid"])
+
+	validated, err := auth.Manager.ValidateToken(token, &jwt.RegisteredClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", validated.(*jwt.RegisteredClaims).Subject)
+
+	var subject string
+	h := auth.Middleware.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims, ok := v4jwt.ClaimsFromContext[*jwt.RegisteredClaims](r.Context()); ok {
+			subject = claims.Subject
+		}
+	}))
+
+	testCases := []struct {
+		name    string
+		method  string
+		path    string
+		cookie  string
+		status  int
+		subject string
+	}{
+		{name: "쿠키 토큰", method: http.MethodGet, path: "/private", cookie: token, status: http.StatusOK, subject: "user-1"},
+		{name: "제외된 경로", method: http.MethodPost, path: "/healthz", status: http.StatusOK},
+		{name: "메서드가 일치하는 제외 prefix", method: http.MethodGet, path: "/public/docs", status: http.StatusOK},
+		{name: "메서드가 다른 제외 prefix", method: http.MethodPost, path: "/public/docs", status: http.StatusUnauthorized},
+		{name: "제외 prefix 를 .. 로 벗어난 경로", method: http.MethodGet, path: "/public/../private", status: http.StatusUnauthorized},
+		{name: "정리하면 제외되는 경로", method: http.MethodPost, path: "/status/../healthz", status: http.StatusOK},
+		{name: "problem 형식 에러", method: http.MethodGet, path: "/private", cookie: "invalid", status: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subject = ""
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "access_token", Value: tc.cookie})
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, tc.subject, subject)
+			if tc.status != http.StatusOK {
+				assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestBuildKeyPair(t *testing.T) {
+	dir := t.TempDir()
+	key, err := keys.Generate("ES384")
+	require.NoError(t, err)
+	signer := key.(crypto.Signer)
+
+	privatePEM, err := keys.EncodePEM(signer)
+	require.NoError(t, err)
+	privateFile := filepath.Join(dir, "private.pem")
+	require.NoError(t, os.WriteFile(privateFile, privatePEM, 0o600))
+
+	jwk, err := keys.NewPublicJWK(signer, "ES384")
+	require.NoError(t, err)
+	b, err := json.Marshal(jwk)
+	require.NoError(t, err)
+	publicFile := filepath.Join(dir, "public.json")
+	require.NoError(t, os.WriteFile(publicFile, b, 0o644))
+
+	signing, err := Build(&Config{Algorithm: "ES384", Key: KeyConfig{PrivateKeyFile: privateFile, PublicKeyFile: publicFile}})
+	require.NoError(t, err)
+	verifying, err := Build(&Config{Algorithm: "ES384", Key: KeyConfig{PublicKeyFile: publicFile}})
+	require.NoError(t, err)
+	assert.Nil(t, verifying.Creator)
+	assert.Nil(t, verifying.Manager)
+
+	claims, err := signing.Claims("user-1")
+	require.NoError(t, err)
+	token, err := signing.Creator.CreateToken(claims)
+	require.NoError(t, err)
+	_, err = verifying.Validator.ValidateToken(token, &jwt.RegisteredClaims{})
+	assert.NoError(t, err)
+}
+
+func TestBuildTokenFormat(t *testing.T) {
+	dir := t.TempDir()
+	key, err := keys.GenerateEd25519()
+	require.NoError(t, err)
+	privatePEM, err := keys.EncodePEM(key)
+	require.NoError(t, err)
+	privateFile := filepath.Join(dir, "ed25519.pem")
+	require.NoError(t, os.WriteFile(privateFile, privatePEM, 0o600))
+
+	testCases := []struct {
+		name   string
+		config *Config
+		prefix string
+	}{
+		{
+			name:   "JWT",
+			config: &Config{Algorithm: "HS256", Key: KeyConfig{Secret: "0123456789abcdef0123456789abcdef"}},
+			prefix: "eyJ",
+		},
+		{
+			name:   "PASETO v4.local",
+			config: &Config{Algorithm: paseto.Local, Key: KeyConfig{Secret: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"}},
+			prefix: "v4.local.",
+		},
+		{
+			name:   "PASETO v4.public",
+			config: &Config{Algorithm: paseto.Public, Key: KeyConfig{PrivateKeyFile: privateFile}},
+			prefix: "v4.public.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.Issuer = "https://auth.example.com"
+			tc.config.Audience = "api"
+			tc.config.TokenTypes = []string{"at+jwt"}
+			tc.config.Exclude = []ExclusionConfig{{Path: "/healthz"}}
+			auth, err := Build(tc.config)
+			require.NoError(t, err)
+
+			claims, err := auth.TokenClaims("user-1")
+			require.NoError(t, err)
+			tokenString, err := auth.TokenManager.CreateToken(claims)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(tokenString, tc.prefix), tokenString)
+
+			validated, err := auth.TokenManager.ValidateToken(tokenString, &token.RegisteredClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, claims, validated)
+
+			var subject string
+			h := auth.TokenMiddleware.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if claims, ok := token.ClaimsFromContext[*token.RegisteredClaims](r.Context()); ok {
+					subject = claims.Subject
+				}
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/private", nil)
+			r.Header.Set("Authorization", "Bearer "+tokenString)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "user-1", subject)
+
+			r = httptest.NewRequest(http.MethodGet, "/healthz", nil)
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, b []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, b, 0o600))
+		return path
+	}
+
+	ecKey, err := keys.Generate("ES256")
+	require.NoError(t, err)
+	ecPEM, err := keys.EncodePEM(ecKey)
+	require.NoError(t, err)
+	otherKey, err := keys.Generate("ES256")
+	require.NoError(t, err)
+	otherPEM, err := keys.EncodePEM(otherKey.(crypto.Signer).Public())
+	require.NoError(t, err)
+
+	ecFile := write("ec.pem", ecPEM)
+	otherFile := write("other.pem", otherPEM)
+	shortSecret := write("secret", []byte("short\n"))
+	edKey, err := keys.GenerateEd25519()
+	require.NoError(t, err)
+	edPEM, err := keys.EncodePEM(edKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		config *Config
+		opts   []BuildOption
+		field  string
+	}{
+		{
+			name:   "설정되지 않은 secret 환경변수",
+			config: &Config{Algorithm: "HS256", Key: KeyConfig{SecretEnv: "TEST_JWT_UNSET_SECRET"}},
+			field:  "key.secret_env",
+		},
+		{
+			name:   "짧은 secret",
+			config: &Config{Algorithm: "HS256", Key: KeyConfig{SecretFile: shortSecret}},
+			field:  "key.secret_file",
+		},
+		{
+			name:   "없는 키 파일",
+			config: &Config{Algorithm: "ES256", Key: KeyConfig{PrivateKeyFile: filepath.Join(dir, "missing.pem")}},
+			field:  "key.private_key_file",
+		},
+		{
+			name:   "알고리즘과 맞지 않는 키",
+			config: &Config{Algorithm: "ES384", Key: KeyConfig{PrivateKeyFile: ecFile}},
+			field:  "key.private_key_file",
+		},
+		{
+			name:   "v4.local 키 길이",
+			config: &Config{Algorithm: paseto.Local, Key: KeyConfig{Secret: "0123456789abcdef0123456789abcdef0123"}},
+			field:  "key.secret",
+		},
+		{
+			name:   "v4.public 에 Ed25519 가 아닌 키",
+			config: &Config{Algorithm: paseto.Public, Key: KeyConfig{PrivateKeyFile: ecFile}},
+			field:  "key.private_key_file",
+		},
+		{
+			name:   "PASETO 에 JWT 클레임 타입",
+			config: &Config{Algorithm: paseto.Local, Key: KeyConfig{Secret: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"}},
+			opts:   []BuildOption{WithClaims(&jwt.RegisteredClaims{})},
+			field:  "algorithm",
+		},
+		{
+			name:   "PASETO 에 JWT 미들웨어 옵션",
+			config: &Config{Algorithm: paseto.Public, Key: KeyConfig{PrivateKeyFile: write("ed25519.pem", edPEM)}},
+			opts:   []BuildOption{WithMiddlewareOptions(v4jwt.WithDPoP(v4jwt.NewDPoPValidator()))},
+			field:  "algorithm",
+		},
+		{
+			name:   "개인키와 맞지 않는 공개키",
+			config: &Config{Algorithm: "ES256", Key: KeyConfig{PrivateKeyFile: ecFile, PublicKeyFile: otherFile}},
+			field:  "key.public_key_file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Build(tc.config, tc.opts...)
+			var fieldErr *FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tc.field, fieldErr.Field)
+		})
+	}
+}
+
+func peekHeader(t *testing.T, token string) map[string]interface{} {
+	t.Helper()
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+	return parsed.Header
+}
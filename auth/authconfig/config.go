@@ -0,0 +1,383 @@
+package authconfig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/paseto"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultEnvPrefix: 환경변수 오버라이드의 기본 접두사 (JWT_ISSUER, JWT_TTL_ACCESS 등)
+const DefaultEnvPrefix = "JWT"
+
+// 설정 가능한 값
+const (
+	ExtractorHeader = "header"
+	ExtractorCookie = "cookie"
+	ExtractorDPoP   = "dpop"
+
+	ErrorFormatJSON    = "json"
+	ErrorFormatProblem = "problem"
+)
+
+// Config: 토큰 발급, 검증과 미들웨어 설정 (YAML 또는 JSON)
+// algorithm 이 v4.local, v4.public 이면 JWT 대신 PASETO 토큰 사용
+//
+//	algorithm: ES256
+//	key:
+//	  id: key-1
+//	  private_key_file: /etc/auth/private.pem
+//	issuer: https://auth.example.com
+//	audience: api
+//	token_types: [at+jwt]
+//	ttl:
+//	  access: 15m
+//	extractors:
+//	  - type: header
+//	  - type: cookie
+//	    name: access_token
+//	exclude:
+//	  - path: /healthz
+//	  - prefix: /public/
+//	    methods: [GET]
+//	error_format: problem
+type Config struct {
+	Algorithm string    `yaml:"algorithm" json:"algorithm"`
+	Key       KeyConfig `yaml:"key" json:"key"`
+	Issuer    string    `yaml:"issuer" json:"issuer"`
+	Audience  string    `yaml:"audience" json:"audience"`
+	// TokenTypes: 허용할 typ 헤더, 첫 번째 값은 발급하는 토큰의 typ
+	TokenTypes []string  `yaml:"token_types" json:"token_types"`
+	TTL        TTLConfig `yaml:"ttl" json:"ttl"`
+	// CacheSize: 검증된 토큰 캐시 크기, 0 이면 캐시하지 않음
+	CacheSize   int               `yaml:"cache_size" json:"cache_size"`
+	Extractors  []ExtractorConfig `yaml:"extractors" json:"extractors"`
+	Exclude     []ExclusionConfig `yaml:"exclude" json:"exclude"`
+	ErrorFormat string            `yaml:"error_format" json:"error_format"`
+}
+
+// KeyConfig: 키 소스, HS*, v4.local 은 secret 중 하나, 그 외는 PEM 또는 JWK 키 파일
+// v4.local 의 secret 은 32 바이트 또는 hex 로 인코딩한 32 바이트
+// 공개키 파일만 설정하면 검증 전용
+type KeyConfig struct {
+	ID             string `yaml:"id" json:"id"`
+	Secret         string `yaml:"secret" json:"secret"`
+	SecretEnv      string `yaml:"secret_env" json:"secret_env"`
+	SecretFile     string `yaml:"secret_file" json:"secret_file"`
+	PrivateKeyFile string `yaml:"private_key_file" json:"private_key_file"`
+	PublicKeyFile  string `yaml:"public_key_file" json:"public_key_file"`
+}
+
+// TTLConfig: 발급하는 토큰의 유효 기간
+type TTLConfig struct {
+	Access  Duration `yaml:"access" json:"access"`
+	Refresh Duration `yaml:"refresh" json:"refresh"`
+}
+
+// ExtractorConfig: 토큰 추출 위치, 나열된 순서대로 시도
+type ExtractorConfig struct {
+	Type string `yaml:"type" json:"type"`
+	// Name: cookie 의 쿠키 이름
+	Name string `yaml:"name" json:"name"`
+}
+
+// ExclusionConfig: 토큰 검증 없이 통과시킬 요청, path 와 prefix 중 하나를 설정
+// methods 가 비어 있으면 모든 메서드
+type ExclusionConfig struct {
+	Path    string   `yaml:"path" json:"path"`
+	Prefix  string   `yaml:"prefix" json:"prefix"`
+	Methods []string `yaml:"methods" json:"methods"`
+}
+
+// Duration: "15m", "720h" 처럼 time.ParseDuration 형식의 문자열로 표현하는 기간
+type Duration time.Duration
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// FieldError: 설정 필드의 에러, Field 는 key.private_key_file, extractors[1].name 처럼 표시
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("config field %s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func fieldError(field string, format string, args ...interface{}) error {
+	return &FieldError{Field: field, Err: fmt.Errorf(format, args...)}
+}
+
+type loadOptions struct {
+	envPrefix string
+	lookupEnv func(string) (string, bool)
+}
+
+type LoadOption func(*loadOptions)
+
+// WithEnvPrefix: 환경변수 오버라이드 접두사, 빈 문자열이면 오버라이드하지 않음
+func WithEnvPrefix(prefix string) LoadOption {
+	return func(o *loadOptions) {
+		o.envPrefix = prefix
+	}
+}
+
+// WithLookupEnv: 환경변수 조회 함수, 기본값은 os.LookupEnv
+func WithLookupEnv(lookup func(string) (string, bool)) LoadOption {
+	return func(o *loadOptions) {
+		o.lookupEnv = lookup
+	}
+}
+
+// Load: 설정 파일을 읽고 환경변수 오버라이드를 적용한 뒤 검증
+func Load(path string, opts ...LoadOption) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := Parse(data, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Parse: YAML 또는 JSON 설정을 파싱하고 환경변수 오버라이드를 적용한 뒤 검증
+// 알 수 없는 필드는 에러
+func Parse(data []byte, opts ...LoadOption) (*Config, error) {
+	o := loadOptions{envPrefix: DefaultEnvPrefix, lookupEnv: os.LookupEnv}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if o.envPrefix != "" {
+		if err := applyEnv(reflect.ValueOf(c).Elem(), o.envPrefix, "", o.lookupEnv); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// applyEnv: yaml 태그 경로로 만든 환경변수 값으로 필드를 덮어씀
+// algorithm 은 JWT_ALGORITHM, key.private_key_file 은 JWT_KEY_PRIVATE_KEY_FILE
+// 문자열 목록은 쉼표로 구분하며, 구조체 목록 (extractors, exclude) 은 지원하지 않음
+func applyEnv(v reflect.Value, envPrefix, fieldPrefix string, lookup func(string) (string, bool)) error {
+	var errs []error
+	for i := 0; i < v.NumField(); i++ {
+		name := strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		field := name
+		if fieldPrefix != "" {
+			field = fieldPrefix + "." + name
+		}
+		env := envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(field, ".", "_"))
+		f := v.Field(i)
+
+		if f.Kind() == reflect.Struct {
+			if err := applyEnv(f, envPrefix, field, lookup); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		value, ok := lookup(env)
+		if !ok {
+			continue
+		}
+		if err := setField(f, value); err != nil {
+			errs = append(errs, fieldError(field, "%s=%q: %w", env, value, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func setField(f reflect.Value, value string) error {
+	if f.Type() == reflect.TypeOf(Duration(0)) {
+		var d Duration
+		if err := d.UnmarshalText([]byte(value)); err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(d))
+		return nil
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(value)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(n))
+	case reflect.Slice:
+		if f.Type().Elem().Kind() != reflect.String {
+			return errors.New("cannot be set from the environment")
+		}
+		var values []string
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+		f.Set(reflect.ValueOf(values))
+	default:
+		return errors.New("cannot be set from the environment")
+	}
+	return nil
+}
+
+// Validate: 모든 필드를 검사하고 잘못된 필드마다 FieldError 반환 (errors.Join)
+// 키 파일, 환경변수의 내용은 Build 에서 확인
+func (c *Config) Validate() error {
+	var errs []error
+
+	method := jwt.GetSigningMethod(c.Algorithm)
+	switch {
+	case c.Algorithm == "":
+		errs = append(errs, fieldError("algorithm", "is required"))
+	case isPASETO(c.Algorithm):
+		errs = append(errs, c.Key.validate(c.Algorithm, c.Algorithm == paseto.Local)...)
+		if c.CacheSize > 0 {
+			errs = append(errs, fieldError("cache_size", "is not supported with %s", c.Algorithm))
+		}
+	case method == nil || method == jwt.SigningMethodNone:
+		errs = append(errs, fieldError("algorithm", "unsupported algorithm %q", c.Algorithm))
+	default:
+		errs = append(errs, c.Key.validate(c.Algorithm, isHMAC(method))...)
+	}
+
+	if c.TTL.Access < 0 {
+		errs = append(errs, fieldError("ttl.access", "must not be negative"))
+	}
+	if c.TTL.Refresh < 0 {
+		errs = append(errs, fieldError("ttl.refresh", "must not be negative"))
+	}
+	if c.CacheSize < 0 {
+		errs = append(errs, fieldError("cache_size", "must not be negative"))
+	}
+
+	for i, e := range c.Extractors {
+		field := fmt.Sprintf("extractors[%d]", i)
+		switch e.Type {
+		case ExtractorHeader, ExtractorDPoP:
+		case ExtractorCookie:
+			if e.Name == "" {
+				errs = append(errs, fieldError(field+".name", "is required for cookie extractors"))
+			}
+		default:
+			errs = append(errs, fieldError(field+".type", "must be one of %s, %s, %s, got %q", ExtractorHeader, ExtractorCookie, ExtractorDPoP, e.Type))
+		}
+	}
+
+	for i, e := range c.Exclude {
+		field := fmt.Sprintf("exclude[%d]", i)
+		switch {
+		case (e.Path == "") == (e.Prefix == ""):
+			errs = append(errs, fieldError(field, "exactly one of path or prefix is required"))
+		case e.Path != "" && !strings.HasPrefix(e.Path, "/"):
+			errs = append(errs, fieldError(field+".path", "must start with /"))
+		case e.Prefix != "" && !strings.HasPrefix(e.Prefix, "/"):
+			errs = append(errs, fieldError(field+".prefix", "must start with /"))
+		}
+		for j, m := range e.Methods {
+			if !isMethod(m) {
+				errs = append(errs, fieldError(fmt.Sprintf("%s.methods[%d]", field, j), "unknown HTTP method %q", m))
+			}
+		}
+	}
+
+	switch c.ErrorFormat {
+	case "", ErrorFormatJSON, ErrorFormatProblem:
+	default:
+		errs = append(errs, fieldError("error_format", "must be %s or %s, got %q", ErrorFormatJSON, ErrorFormatProblem, c.ErrorFormat))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate: symmetric 이면 secret, 아니면 키 파일
+func (k KeyConfig) validate(alg string, symmetric bool) []error {
+	var errs []error
+	secrets := 0
+	for _, s := range []string{k.Secret, k.SecretEnv, k.SecretFile} {
+		if s != "" {
+			secrets++
+		}
+	}
+
+	if symmetric {
+		if secrets != 1 {
+			errs = append(errs, fieldError("key", "exactly one of secret, secret_env or secret_file is required for %s", alg))
+		}
+		if k.PrivateKeyFile != "" {
+			errs = append(errs, fieldError("key.private_key_file", "is not used with %s", alg))
+		}
+		if k.PublicKeyFile != "" {
+			errs = append(errs, fieldError("key.public_key_file", "is not used with %s", alg))
+		}
+		return errs
+	}
+
+	if secrets > 0 {
+		errs = append(errs, fieldError("key", "secret, secret_env and secret_file are only used with HS* and %s algorithms", paseto.Local))
+	}
+	if k.PrivateKeyFile == "" && k.PublicKeyFile == "" {
+		errs = append(errs, fieldError("key", "private_key_file or public_key_file is required for %s", alg))
+	}
+	return errs
+}
+
+func isHMAC(method jwt.SigningMethod) bool {
+	_, ok := method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+func isPASETO(alg string) bool {
+	return alg == paseto.Local || alg == paseto.Public
+}
+
+func isMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,218 @@
+package authconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testYAML = `
+algorithm: HS256
+key:
+  id: key-1
+  secret_env: TEST_JWT_SECRET
+issuer: https://auth.example.com
+audience: api
+token_types: [at+jwt]
+ttl:
+  access: 10m
+  refresh: 720h
+cache_size: 128
+extractors:
+  - type: header
+  - type: cookie
+    name: access_token
+exclude:
+  - path: /healthz
+  - prefix: /public/
+    methods: [GET]
+error_format: problem
+`
+
+func noEnv(string) (string, bool) {
+	return "", false
+}
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(testYAML), WithLookupEnv(noEnv))
+	require.NoError(t, err)
+
+	assert.Equal(t, &Config{
+		Algorithm:   "HS256",
+		Key:         KeyConfig{ID: "key-1", SecretEnv: "TEST_JWT_SECRET"},
+		Issuer:      "https://auth.example.com",
+		Audience:    "api",
+		TokenTypes:  []string{"at+jwt"},
+		TTL:         TTLConfig{Access: Duration(10 * time.Minute), Refresh: Duration(720 * time.Hour)},
+		CacheSize:   128,
+		Extractors:  []ExtractorConfig{{Type: "header"}, {Type: "cookie", Name: "access_token"}},
+		Exclude:     []ExclusionConfig{{Path: "/healthz"}, {Prefix: "/public/", Methods: []string{"GET"}}},
+		ErrorFormat: "problem",
+	}, c)
+
+	t.Run("JSON 설정", func(t *testing.T) {
+		jsonConfig, err := Parse([]byte(`{
+			"algorithm": "HS256",
+			"key": {"id": "key-1", "secret_env": "TEST_JWT_SECRET"},
+			"issuer": "https://auth.example.com",
+			"audience": "api",
+			"token_types": ["at+jwt"],
+			"ttl": {"access": "10m", "refresh": "720h"},
+			"cache_size": 128,
+			"extractors": [{"type": "header"}, {"type": "cookie", "name": "access_token"}],
+			"exclude": [{"path": "/healthz"}, {"prefix": "/public/", "methods": ["GET"]}],
+			"error_format": "problem"
+		}`), WithLookupEnv(noEnv))
+		require.NoError(t, err)
+		assert.Equal(t, c, jsonConfig)
+	})
+
+	t.Run("파일에서 읽기", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "auth.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(testYAML), 0o600))
+		fileConfig, err := Load(path, WithLookupEnv(noEnv))
+		require.NoError(t, err)
+		assert.Equal(t, c, fileConfig)
+	})
+
+	t.Run("알 수 없는 필드", func(t *testing.T) {
+		_, err := Parse([]byte("algorithm: HS256\nissuers: https://auth.example.com\n"), WithLookupEnv(noEnv))
+		assert.ErrorContains(t, err, "field issuers not found")
+	})
+}
+
+func TestEnvOverrides(t *testing.T) {
+	env := map[string]string{
+		"APP_ISSUER":          "https://override.example.com",
+		"APP_KEY_SECRET_ENV":  "OTHER_SECRET",
+		"APP_TTL_ACCESS":      "5m",
+		"APP_TOKEN_TYPES":     "at+jwt, application/at+jwt",
+		"APP_CACHE_SIZE":      "0",
+		"APP_ERROR_FORMAT":    "json",
+		"JWT_AUDIENCE":        "ignored",
+		"APP_UNKNOWN_SETTING": "ignored",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	c, err := Parse([]byte(testYAML), WithEnvPrefix("APP"), WithLookupEnv(lookup))
+	require.NoError(t, err)
+	assert.Equal(t, "https://override.example.com", c.Issuer)
+	assert.Equal(t, "api", c.Audience)
+	assert.Equal(t, "OTHER_SECRET", c.Key.SecretEnv)
+	assert.Equal(t, Duration(5*time.Minute), c.TTL.Access)
+	assert.Equal(t, []string{"at+jwt", "application/at+jwt"}, c.TokenTypes)
+	assert.Equal(t, 0, c.CacheSize)
+	assert.Equal(t, "json", c.ErrorFormat)
+
+	t.Run("잘못된 값은 환경변수 이름과 함께 표시", func(t *testing.T) {
+		env["APP_TTL_ACCESS"] = "soon"
+		_, err := Parse([]byte(testYAML), WithEnvPrefix("APP"), WithLookupEnv(lookup))
+
+		var fieldErr *FieldError
+		require.ErrorAs(t, err, &fieldErr)
+		assert.Equal(t, "ttl.access", fieldErr.Field)
+		assert.ErrorContains(t, err, `APP_TTL_ACCESS="soon"`)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		yaml   string
+		fields []string
+	}{
+		{
+			name:   "알고리즘 누락",
+			yaml:   "key: {secret: x}",
+			fields: []string{"algorithm"},
+		},
+		{
+			name:   "none 알고리즘",
+			yaml:   "algorithm: none",
+			fields: []string{"algorithm"},
+		},
+		{
+			name:   "HMAC 에 secret 이 여러 개",
+			yaml:   "algorithm: HS256\nkey: {secret: x, secret_file: /tmp/secret}",
+			fields: []string{"key"},
+		},
+		{
+			name:   "HMAC 에 키 파일",
+			yaml:   "algorithm: HS256\nkey: {secret: x, private_key_file: /tmp/key.pem}",
+			fields: []string{"key.private_key_file"},
+		},
+		{
+			name:   "비대칭 알고리즘에 키 파일 누락",
+			yaml:   "algorithm: ES256\nkey: {secret: x}",
+			fields: []string{"key", "key"},
+		},
+		{
+			name:   "PASETO 에 cache_size",
+			yaml:   "algorithm: v4.local\nkey: {secret: x}\ncache_size: 128",
+			fields: []string{"cache_size"},
+		},
+		{
+			name:   "v4.public 에 secret",
+			yaml:   "algorithm: v4.public\nkey: {secret: x}",
+			fields: []string{"key", "key"},
+		},
+		{
+			name: "extractor, exclusion, error_format 오류",
+			yaml: `
+algorithm: ES256
+key: {public_key_file: /tmp/public.pem}
+ttl: {access: -1m}
+extractors:
+  - type: header
+  - type: cookie
+  - type: query
+exclude:
+  - path: /healthz
+    prefix: /health
+  - prefix: public/
+  - path: /status
+    methods: [get]
+error_format: xml
+`,
+			fields: []string{"ttl.access", "extractors[1].name", "extractors[2].type", "exclude[0]", "exclude[1].prefix", "exclude[2].methods[0]", "error_format"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.yaml), WithLookupEnv(noEnv))
+			require.Error(t, err)
+			assert.Equal(t, tc.fields, errorFields(err))
+		})
+	}
+}
+
+// errorFields: errors.Join 으로 합쳐진 FieldError 의 필드 목록
+func errorFields(err error) []string {
+	var fields []string
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		joined = joinedErrors{err}
+	}
+	for _, e := range joined.Unwrap() {
+		var fieldErr *FieldError
+		if errors.As(e, &fieldErr) {
+			fields = append(fields, fieldErr.Field)
+		}
+	}
+	return fields
+}
+
+type joinedErrors []error
+
+func (e joinedErrors) Unwrap() []error {
+	return e
+}
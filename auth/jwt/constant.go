@@ -1,38 +1,45 @@
 package jwt
 
 import (
-	"github.com/golang-jwt/jwt/v4"
+	"errors"
+
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
 )
 
 /**
-* golang-jwt 에서 제공하는 Error Constant 와 동일한 값을 사용
+* v4jwt 에서 제공하는 Error Constant 와 동일한 값을 사용
 * App 에서 생성한 jwt 패키지만 사용하기 위해 동일한 값을 사용
 * 필요한 상수는 추가해서 사용
 * 참고 : https://pkg.go.dev/github.com/golang-jwt/jwt/v5#pkg-constants
  */
 
 var (
-	ErrInvalidKey                = jwt.ErrInvalidKey
-	ErrInvalidKeyType            = jwt.ErrInvalidKeyType
-	ErrHashUnavailable           = jwt.ErrHashUnavailable
-	ErrTokenMalformed            = jwt.ErrTokenMalformed
-	ErrTokenUnverifiable         = jwt.ErrTokenUnverifiable
-	ErrTokenSignatureInvalid     = jwt.ErrTokenSignatureInvalid
-	ErrTokenInvalidAudience      = jwt.ErrTokenInvalidAudience
-	ErrTokenExpired              = jwt.ErrTokenExpired
-	ErrTokenUsedBeforeIssued     = jwt.ErrTokenUsedBeforeIssued
-	ErrTokenInvalidIssuer        = jwt.ErrTokenInvalidIssuer
-	ErrTokenNotValidYet          = jwt.ErrTokenNotValidYet
-	ErrTokenInvalidId            = jwt.ErrTokenInvalidId
-	ErrTokenInvalidClaims        = jwt.ErrTokenInvalidClaims
+	ErrInvalidKey            = v4jwt.ErrInvalidKey
+	ErrInvalidKeyType        = v4jwt.ErrInvalidKeyType
+	ErrHashUnavailable       = v4jwt.ErrHashUnavailable
+	ErrTokenMalformed        = v4jwt.ErrTokenMalformed
+	ErrTokenUnverifiable     = v4jwt.ErrTokenUnverifiable
+	ErrTokenSignatureInvalid = v4jwt.ErrTokenSignatureInvalid
+	ErrTokenInvalidAudience  = v4jwt.ErrTokenInvalidAudience
+	ErrTokenExpired          = v4jwt.ErrTokenExpired
+	ErrTokenUsedBeforeIssued = v4jwt.ErrTokenUsedBeforeIssued
+	ErrTokenInvalidIssuer    = v4jwt.ErrTokenInvalidIssuer
+	ErrTokenNotValidYet      = v4jwt.ErrTokenNotValidYet
+	ErrTokenInvalidId        = v4jwt.ErrTokenInvalidId
+	ErrTokenInvalidClaims    = v4jwt.ErrTokenInvalidClaims
 )
 
 var (
-	ErrNotECPublicKey  = jwt.ErrNotECPublicKey
-	ErrNotECPrivateKey = jwt.ErrNotECPrivateKey
+	ErrNotECPublicKey  = v4jwt.ErrNotECPublicKey
+	ErrNotECPrivateKey = v4jwt.ErrNotECPrivateKey
 )
 
 var (
-	ErrNotEdPrivateKey = jwt.ErrNotEdPrivateKey
-	ErrNotEdPublicKey  = jwt.ErrNotEdPublicKey
-)
\ No newline at end of file
+	ErrNotEdPrivateKey = v4jwt.ErrNotEdPrivateKey
+	ErrNotEdPublicKey  = v4jwt.ErrNotEdPublicKey
+)
+
+var (
+	ErrJwtMissing = v4jwt.ErrJwtMissing
+	ErrNoKey      = errors.New("jwt: one of WithManager, WithConfig or WithSecret is required")
+)
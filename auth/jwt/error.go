@@ -2,32 +2,35 @@ package jwt
 
 import (
 	"errors"
-	"fmt"
-
-	"github.com/golang-jwt/jwt/v4"
 )
 
-// 토큰 오류 함수 처리
-func handleJwtError(token *jwt.Token, err error) error {
-	// jwt.Parse, jwt.ParseWithClaims 에서 토큰 파싱에 실패하는 경우 token 이 nil 이 됨 
-	// 1. header.payload.signature 형식이 아닌 경우 
-	// 2. 각 부분이 Base64 로 인코딩 되지 않은 경우 
-	if token == nil { 
-        if errors.Is(err, jwt.ErrTokenMalformed) {
-            return errors.New("token is malformed")
-        }
-        return fmt.Errorf("invalid token: %v", err)
-	}
+// tokenError: 기존 에러 메시지를 유지하면서 errors.Is 로 원인을 확인할 수 있도록 감싼 에러
+type tokenError struct {
+	message string
+	err     error
+}
 
+func (e *tokenError) Error() string {
+	return e.message
+}
+
+func (e *tokenError) Unwrap() error {
+	return e.err
+}
+
+// 토큰 오류 함수 처리
+func handleJwtError(err error) error {
 	switch {
-	case token.Valid:
-		return nil 
-	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
-		return errors.New("token signature is invalid")
-	case errors.Is(err, jwt.ErrTokenExpired) || errors.Is(err, jwt.ErrTokenNotValidYet):
+	case errors.Is(err, ErrTokenMalformed):
+		// 1. header.payload.signature 형식이 아닌 경우
+		// 2. 각 부분이 Base64 로 인코딩 되지 않은 경우
+		return &tokenError{message: "token is malformed", err: err}
+	case errors.Is(err, ErrTokenSignatureInvalid):
+		return &tokenError{message: "token signature is invalid", err: err}
+	case errors.Is(err, ErrTokenExpired) || errors.Is(err, ErrTokenNotValidYet):
 		// Token is expired or not valid yet
-		return errors.New("token is expired or not valid yet") 
+		return &tokenError{message: "token is expired or not valid yet", err: err}
 	default:
-		return errors.New("Could not handle this token error")
+		return &tokenError{message: "Could not handle this token error", err: err}
 	}
-}
\ No newline at end of file
+}
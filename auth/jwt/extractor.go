@@ -4,9 +4,11 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
 )
 
-type TokenExtractor func(r *http.Request) (string, error)
+type TokenExtractor = v4jwt.Extractor
 
 // Bearer 헤더에서 토큰 추출
 func extractTokenFromBearerString(bearerString string) (string, error) {
@@ -35,3 +37,13 @@ func extractTokenFromCookie(cookieString string) TokenExtractor {
 		return cookie.Value, nil
 	}
 }
+
+// BearerExtractor: Authorization: Bearer <token> 에서 토큰 추출
+func BearerExtractor(r *http.Request) (string, error) {
+	return extractTokenFromBearerString(r.Header.Get("Authorization"))
+}
+
+// CookieExtractor: 쿠키에서 토큰 추출
+func CookieExtractor(cookieName string) TokenExtractor {
+	return extractTokenFromCookie(cookieName)
+}
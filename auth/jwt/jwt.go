@@ -1,9 +1,12 @@
 package jwt
 
 import (
-	"fmt"
+	"crypto/rand"
+	"sync"
+	"sync/atomic"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
 )
 
 /**
@@ -19,69 +22,179 @@ type Jwt interface {
 /**
 * 커스텀 클레임 타입
 * 필요한 경우 사용
-*/
+ */
 type CustomClaims struct {
 	UserId string `json:"user_id"`
 	jwt.RegisteredClaims
 }
 
-// key 변수는 Boilerplate 작성을 위한 예제 값입니다.
-// Signing key 와 Verifying key 는 안전하게 관리해주시기 바랍니다.
-var (
-	key []byte = []byte("sample-key")
-	t *jwt.Token
-	s string 
-)
+// Service: v4jwt.Manager 를 감싼 Jwt 구현
+// 상태를 공유하지 않으므로 여러 고루틴에서 동시에 사용 가능
+type Service struct {
+	manager      v4jwt.Manager[jwt.Claims]
+	extractor    TokenExtractor
+	errorHandler v4jwt.ErrorHandler
+}
 
+type options struct {
+	manager          v4jwt.Manager[jwt.Claims]
+	config           *v4jwt.Config
+	validatorOptions []v4jwt.ValidatorOption
+	extractor        TokenExtractor
+	errorHandler     v4jwt.ErrorHandler
+}
 
-/** 
-* 기본 토큰 생성 함수 
-*/
-func CreateToken() (string, error) {
-	t = jwt.New(jwt.SigningMethodHS256) // 원하는 Signing Method 를 선택 
-	return t.SignedString(key)
+type Option func(*options)
+
+// WithManager: 토큰 생성, 검증에 사용할 Manager, 설정되면 WithConfig 와 WithSecret 은 무시
+func WithManager(manager v4jwt.Manager[jwt.Claims]) Option {
+	return func(o *options) {
+		o.manager = manager
+	}
+}
+
+// WithConfig: 알고리즘과 키 설정 (비대칭 키, v4jwt.NewKeyRingConfig 등)
+func WithConfig(config *v4jwt.Config) Option {
+	return func(o *options) {
+		o.config = config
+	}
+}
+
+// WithSecret: HS256 secret 으로 설정
+func WithSecret(secret []byte) Option {
+	return WithConfig(v4jwt.NewConfig(jwt.SigningMethodHS256, secret))
+}
+
+// WithValidatorOptions: WithConfig, WithSecret 으로 만드는 Validator 의 옵션 (issuer, audience 등)
+func WithValidatorOptions(opts ...v4jwt.ValidatorOption) Option {
+	return func(o *options) {
+		o.validatorOptions = append(o.validatorOptions, opts...)
+	}
+}
+
+// WithExtractor: AuthMiddleware 의 토큰 추출 방식, 기본값은 BearerExtractor
+func WithExtractor(extractor TokenExtractor) Option {
+	return func(o *options) {
+		o.extractor = extractor
+	}
+}
+
+// WithErrorHandler: AuthMiddleware 의 인증 실패 응답, 기본값은 "Unauthorized: <에러>" 401 응답
+func WithErrorHandler(handler v4jwt.ErrorHandler) Option {
+	return func(o *options) {
+		o.errorHandler = handler
+	}
+}
+
+func New(opts ...Option) (*Service, error) {
+	o := &options{
+		extractor:    BearerExtractor,
+		errorHandler: unauthorizedHandler,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	manager := o.manager
+	if manager == nil {
+		if o.config == nil {
+			return nil, ErrNoKey
+		}
+		manager = v4jwt.NewTokenManager[jwt.Claims](
+			v4jwt.NewCreator(o.config),
+			v4jwt.NewValidator[jwt.Claims](o.config, o.validatorOptions...),
+		)
+	}
+
+	return &Service{
+		manager:      manager,
+		extractor:    o.extractor,
+		errorHandler: o.errorHandler,
+	}, nil
 }
 
 /**
-* 클레임을 포함한 토큰 생성 함수 
-*/
-func CreateTokenWithClaims(claims jwt.Claims) (string, error) {
-	t = jwt.NewWithClaims(jwt.SigningMethodHS256, claims) // 원하는 Signing Method 선택 
-	return t.SignedString(key)
+* 기본 토큰 생성 함수
+ */
+func (s *Service) CreateToken() (string, error) {
+	return s.manager.CreateToken(nil)
 }
 
 /**
-* 토큰 파싱 함수 
-*/
-func ParseToken(tokenString string) (jwt.Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok { // 
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-		}
-		return key, nil
-	})
+* 클레임을 포함한 토큰 생성 함수
+ */
+func (s *Service) CreateTokenWithClaims(claims jwt.Claims) (string, error) {
+	return s.manager.CreateToken(claims)
+}
 
-	if err != nil { 
-		return nil, handleJwtError(token, err)
+/**
+* 토큰 파싱 함수
+ */
+func (s *Service) ParseToken(tokenString string) (jwt.Claims, error) {
+	claims, err := s.manager.ValidateToken(tokenString, &jwt.RegisteredClaims{})
+	if err != nil {
+		return nil, handleJwtError(err)
 	}
-
-	return token.Claims, nil
+	return claims, nil
 }
 
 /**
-* 커스텀 클레임을 포함한 토큰 파싱 함수 
-*/
-func ParseTokenWithAppClaims(tokenString string) (*CustomClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token)(interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+* 커스텀 클레임을 포함한 토큰 파싱 함수
+ */
+func (s *Service) ParseTokenWithAppClaims(tokenString string) (*CustomClaims, error) {
+	claims, err := s.manager.ValidateToken(tokenString, &CustomClaims{})
+	if err != nil {
+		return nil, handleJwtError(err)
+	}
+	// WithManager 로 설정한 Manager 가 다른 타입의 클레임을 반환하는 경우
+	customClaims, ok := claims.(*CustomClaims)
+	if !ok {
+		return nil, ErrTokenInvalidClaims
+	}
+	return customClaims, nil
+}
+
+var (
+	defaultService     atomic.Pointer[Service]
+	defaultServiceOnce sync.Once
+)
+
+// SetDefault: 패키지 함수가 사용할 Service 설정
+// main 이나 init 에서 실제 키로 설정한 Service 를 등록
+func SetDefault(s *Service) {
+	defaultService.Store(s)
+}
+
+// Default: SetDefault 로 등록한 Service
+// 등록하지 않은 경우 프로세스마다 새로 생성한 랜덤 secret 을 사용하므로
+// 재시작하거나 여러 인스턴스로 실행하면 다른 곳에서 발급한 토큰은 검증되지 않음
+func Default() *Service {
+	if s := defaultService.Load(); s != nil {
+		return s
+	}
+	defaultServiceOnce.Do(func() {
+		secret := make([]byte, 64)
+		if _, err := rand.Read(secret); err != nil {
+			panic(err)
 		}
-		return key, nil
+		s, _ := New(WithSecret(secret))
+		defaultService.CompareAndSwap(nil, s)
 	})
+	return defaultService.Load()
+}
 
-	if err != nil {
-		return nil, handleJwtError(token, err)
-	}
+func CreateToken() (string, error) {
+	return Default().CreateToken()
+}
 
-	return token.Claims.(*CustomClaims), nil
-}
\ No newline at end of file
+func CreateTokenWithClaims(claims jwt.Claims) (string, error) {
+	return Default().CreateTokenWithClaims(claims)
+}
+
+func ParseToken(tokenString string) (jwt.Claims, error) {
+	return Default().ParseToken(tokenString)
+}
+
+func ParseTokenWithAppClaims(tokenString string) (*CustomClaims, error) {
+	return Default().ParseTokenWithAppClaims(tokenString)
+}
@@ -1,11 +1,15 @@
 package jwt
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateToken(t *testing.T) {
@@ -170,3 +174,108 @@ func createNewTestTokenWithCustomClaims() (string, error) {
 	tokenString, err := CreateTokenWithClaims(claims)
 	return tokenString, err
 }
+
+func TestServiceConcurrency(t *testing.T) {
+	service, err := New(WithSecret([]byte("concurrency-test-secret-0123456789")))
+	require.NoError(t, err)
+
+	// 발급과 검증이 공유 상태 없이 동작하는지 확인 (go test -race)
+	const workers = 32
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			subject := fmt.Sprintf("user-%d", i)
+			tokenString, err := service.CreateTokenWithClaims(&CustomClaims{
+				UserId: subject,
+				RegisteredClaims: jwt.RegisteredClaims{
+					Subject:   subject,
+					ExpiresAt: jwt.NewNumericDate(jwt.TimeFunc().Add(time.Hour)),
+				},
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+			claims, err := service.ParseTokenWithAppClaims(tokenString)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if claims.UserId != subject || claims.Subject != subject {
+				errs <- fmt.Errorf("claims of %s: got %s", subject, claims.UserId)
+				return
+			}
+			// 기본 Service 도 함께 사용
+			if _, err := CreateToken(); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestService(t *testing.T) {
+	secret := []byte("service-test-secret-0123456789abcdef")
+	service, err := New(WithSecret(secret), WithValidatorOptions(v4jwt.WithIssuer("test")))
+	require.NoError(t, err)
+
+	t.Run("키 설정 없음", func(t *testing.T) {
+		_, err := New()
+		assert.ErrorIs(t, err, ErrNoKey)
+	})
+
+	t.Run("issuer 검증", func(t *testing.T) {
+		tokenString, err := service.CreateTokenWithClaims(&jwt.RegisteredClaims{Issuer: "other"})
+		require.NoError(t, err)
+		_, err = service.ParseToken(tokenString)
+		assert.ErrorIs(t, err, ErrTokenInvalidIssuer)
+	})
+
+	t.Run("에러 메시지는 유지하면서 원인 확인 가능", func(t *testing.T) {
+		other, err := New(WithSecret([]byte("another-test-secret-0123456789abcdef")))
+		require.NoError(t, err)
+		tokenString, err := other.CreateTokenWithClaims(&jwt.RegisteredClaims{Issuer: "test"})
+		require.NoError(t, err)
+
+		_, err = service.ParseToken(tokenString)
+		assert.EqualError(t, err, "token signature is invalid")
+		assert.ErrorIs(t, err, ErrTokenSignatureInvalid)
+	})
+
+	t.Run("Manager 가 커스텀 클레임이 아닌 타입을 반환", func(t *testing.T) {
+		other, err := New(WithManager(registeredClaimsManager{service.manager}))
+		require.NoError(t, err)
+		tokenString, err := other.CreateTokenWithClaims(&CustomClaims{UserId: "user-1", RegisteredClaims: jwt.RegisteredClaims{Issuer: "test"}})
+		require.NoError(t, err)
+
+		_, err = other.ParseTokenWithAppClaims(tokenString)
+		assert.ErrorIs(t, err, ErrTokenInvalidClaims)
+	})
+
+	t.Run("기본 Service 교체", func(t *testing.T) {
+		previous := Default()
+		SetDefault(service)
+		t.Cleanup(func() { SetDefault(previous) })
+
+		tokenString, err := service.CreateTokenWithClaims(&jwt.RegisteredClaims{Issuer: "test"})
+		require.NoError(t, err)
+		_, err = ParseToken(tokenString)
+		assert.NoError(t, err)
+	})
+}
+
+// registeredClaimsManager: 전달받은 클레임 타입과 관계없이 RegisteredClaims 로 검증하는 Manager
+type registeredClaimsManager struct {
+	v4jwt.Manager[jwt.Claims]
+}
+
+func (m registeredClaimsManager) ValidateToken(tokenString string, _ jwt.Claims) (jwt.Claims, error) {
+	return m.Manager.ValidateToken(tokenString, &jwt.RegisteredClaims{})
+}
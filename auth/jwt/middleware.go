@@ -3,34 +3,46 @@ package jwt
 import (
 	"context"
 	"net/http"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
 )
 
-// Jwt 토큰 검증 및 Context 에 클레임 저장
-// 요구사항에 따라서 Error 핸들링 부분 수정
-func AuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Authorization 헤더에서 토큰 추출 
-		// 만약 Bearer 를 사용하지 않으면 새로운 Extractor 를 생성해서 사용 
-		authHeader := r.Header.Get("Authorization")
-		token, err := extractTokenFromBearerString(authHeader)
+// UserContextKey: 이전 AuthMiddleware 가 클레임을 저장하던 Context 키
+//
+// Deprecated: ClaimsFromContext 를 사용, 기존 r.Context().Value("user") 호출을 위해서만 유지
+const UserContextKey = "user"
 
-		if err != nil { 
-			// Header is Empty || Invalid Format
-			http.Error(w, "Unauthorized: " + err.Error(), http.StatusUnauthorized)
-			return
+// AuthMiddleware: Jwt 토큰 검증 및 Context 에 클레임 저장
+// 클레임은 ClaimsFromContext 로 조회, 호환을 위해 UserContextKey 에도 jwt.Claims 로 저장
+func (s *Service) AuthMiddleware(next http.Handler) http.Handler {
+	validator := v4jwt.ClaimsValidator[jwt.Claims](s.manager)
+	middleware := v4jwt.NewJwtMiddleware(s.extractor, validator, s.errorHandler, &jwt.RegisteredClaims{})
+	return middleware.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims, ok := ClaimsFromContext(r.Context()); ok {
+			r = r.WithContext(context.WithValue(r.Context(), UserContextKey, jwt.Claims(claims)))
 		}
+		next.ServeHTTP(w, r)
+	}))
+}
 
-		// 토큰 검증 및 클레임 파싱 
-		// 클레임 파싱 시 커스텀 클레임을 사용하는 경우 ParseTokenWithAppClaims 사용  
-		claims, err := ParseToken(token)
+// AuthMiddleware: 기본 Service 의 AuthMiddleware
+func AuthMiddleware(next http.Handler) http.Handler {
+	return Default().AuthMiddleware(next)
+}
 
-		if err != nil { 
-			// 토큰 검증 실패 
-			http.Error(w, "Unauthorized: " + err.Error(), http.StatusUnauthorized)
-			return
-		}
+// ClaimsFromContext: AuthMiddleware 가 저장한 클레임
+func ClaimsFromContext(ctx context.Context) (*jwt.RegisteredClaims, bool) {
+	return v4jwt.ClaimsFromContext[*jwt.RegisteredClaims](ctx)
+}
 
-		ctx := context.WithValue(r.Context(), "user", claims) 
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
-}
\ No newline at end of file
+// unauthorizedHandler: 헤더가 없거나 형식이 잘못된 경우, 토큰 검증에 실패한 경우 401 응답
+// 헤더 에러는 그대로, 토큰 검증 에러는 ParseToken 과 같은 메시지로 응답
+func unauthorizedHandler(w http.ResponseWriter, r *http.Request, err error) {
+	switch v4jwt.ErrorClass(err) {
+	case "missing", "other":
+	default:
+		err = handleJwtError(err)
+	}
+	http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+}
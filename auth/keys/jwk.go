@@ -0,0 +1,257 @@
+package keys
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+var (
+	ErrInvalidJWK  = errors.New("invalid jwk")
+	ErrNoPublicKey = errors.New("symmetric keys have no public part")
+)
+
+// PrivateJWK: 개인키 멤버를 포함한 JSON Web Key (RFC 7518 Section 6)
+// 공개 멤버는 v4jwt.JWK 와 같고, 대칭키는 kty "oct" 와 k 로 표현
+type PrivateJWK struct {
+	v4jwt.JWK
+	// RSA, EC, OKP
+	D string `json:"d,omitempty"`
+	// RSA
+	P  string `json:"p,omitempty"`
+	Q  string `json:"q,omitempty"`
+	DP string `json:"dp,omitempty"`
+	DQ string `json:"dq,omitempty"`
+	QI string `json:"qi,omitempty"`
+	// oct
+	K string `json:"k,omitempty"`
+}
+
+// NewPublicJWK: 공개키 (또는 개인키의 공개키) 의 JWK, kid 는 RFC 7638 thumbprint
+func NewPublicJWK(key interface{}, alg string) (v4jwt.JWK, error) {
+	public, err := Public(key)
+	if err != nil {
+		return v4jwt.JWK{}, err
+	}
+	jwk, err := v4jwt.NewJWK(public, "", alg)
+	if err != nil {
+		return v4jwt.JWK{}, err
+	}
+	if jwk.Kid, err = jwk.Thumbprint(); err != nil {
+		return v4jwt.JWK{}, err
+	}
+	return jwk, nil
+}
+
+// NewPrivateJWK: 개인키 또는 HMAC secret 의 JWK, kid 는 RFC 7638 thumbprint
+func NewPrivateJWK(key interface{}, alg string) (PrivateJWK, error) {
+	if secret, ok := key.([]byte); ok {
+		jwk := PrivateJWK{
+			JWK: v4jwt.JWK{Kty: "oct", Alg: alg, Use: "sig"},
+			K:   base64.RawURLEncoding.EncodeToString(secret),
+		}
+		jwk.Kid, _ = jwk.Thumbprint()
+		return jwk, nil
+	}
+
+	public, err := NewPublicJWK(key, alg)
+	if err != nil {
+		return PrivateJWK{}, err
+	}
+	jwk := PrivateJWK{JWK: public}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if len(k.Primes) != 2 {
+			return PrivateJWK{}, fmt.Errorf("%w: multi-prime RSA keys", ErrUnsupportedKey)
+		}
+		k.Precompute()
+		jwk.D = encodeBigInt(k.D)
+		jwk.P = encodeBigInt(k.Primes[0])
+		jwk.Q = encodeBigInt(k.Primes[1])
+		jwk.DP = encodeBigInt(k.Precomputed.Dp)
+		jwk.DQ = encodeBigInt(k.Precomputed.Dq)
+		jwk.QI = encodeBigInt(k.Precomputed.Qinv)
+	case *ecdsa.PrivateKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		jwk.D = base64.RawURLEncoding.EncodeToString(k.D.FillBytes(make([]byte, size)))
+	case ed25519.PrivateKey:
+		jwk.D = base64.RawURLEncoding.EncodeToString(k.Seed())
+	default:
+		return PrivateJWK{}, fmt.Errorf("%w: a private key is required", ErrUnsupportedKey)
+	}
+	return jwk, nil
+}
+
+// IsPrivate: 개인키 또는 대칭키 멤버 포함 여부
+func (k PrivateJWK) IsPrivate() bool {
+	return k.D != "" || k.K != ""
+}
+
+// Thumbprint: RFC 7638 thumbprint, oct 키는 k 와 kty 로 계산
+func (k PrivateJWK) Thumbprint() (string, error) {
+	if k.Kty != "oct" {
+		return k.JWK.Thumbprint()
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf(`{"k":%q,"kty":"oct"}`, k.K)))
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// Public: 개인키 멤버를 제외한 공개 JWK
+func (k PrivateJWK) Public() (v4jwt.JWK, error) {
+	if k.Kty == "oct" {
+		return v4jwt.JWK{}, ErrNoPublicKey
+	}
+	return k.JWK, nil
+}
+
+// Key: JWK 를 crypto 패키지의 키로 변환
+// 개인키 멤버가 있으면 개인키, oct 는 []byte, 그 외는 공개키
+func (k PrivateJWK) Key() (interface{}, error) {
+	if k.Kty == "oct" {
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil || len(secret) == 0 {
+			return nil, fmt.Errorf("%w: k", ErrInvalidJWK)
+		}
+		return secret, nil
+	}
+
+	public, err := k.JWK.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if k.D == "" {
+		return public, nil
+	}
+
+	switch pub := public.(type) {
+	case *rsa.PublicKey:
+		return k.rsaKey(pub)
+	case *ecdsa.PublicKey:
+		d, err := decodeBigInt(k.D)
+		if err != nil {
+			return nil, fmt.Errorf("%w: d", ErrInvalidJWK)
+		}
+		key := &ecdsa.PrivateKey{PublicKey: *pub, D: d}
+		if err := checkECKey(key); err != nil {
+			return nil, err
+		}
+		return key, nil
+	case ed25519.PublicKey:
+		seed, err := base64.RawURLEncoding.DecodeString(k.D)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("%w: d", ErrInvalidJWK)
+		}
+		key := ed25519.NewKeyFromSeed(seed)
+		if !bytes.Equal(key.Public().(ed25519.PublicKey), pub) {
+			return nil, fmt.Errorf("%w: d does not match x", ErrInvalidJWK)
+		}
+		return key, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+func (k PrivateJWK) rsaKey(pub *rsa.PublicKey) (*rsa.PrivateKey, error) {
+	names := []string{"d", "p", "q"}
+	values := make([]*big.Int, len(names))
+	for i, value := range []string{k.D, k.P, k.Q} {
+		n, err := decodeBigInt(value)
+		if err != nil || n.Sign() == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidJWK, names[i])
+		}
+		values[i] = n
+	}
+
+	key := &rsa.PrivateKey{
+		PublicKey: *pub,
+		D:         values[0],
+		Primes:    []*big.Int{values[1], values[2]},
+	}
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJWK, err)
+	}
+	key.Precompute()
+	return key, nil
+}
+
+// checkECKey: d 로 계산한 공개키가 x, y 와 일치하는지 확인
+func checkECKey(key *ecdsa.PrivateKey) error {
+	private, err := key.ECDH()
+	if err != nil {
+		return fmt.Errorf("%w: d", ErrInvalidJWK)
+	}
+	public, err := key.PublicKey.ECDH()
+	if err != nil {
+		return fmt.Errorf("%w: x, y", ErrInvalidJWK)
+	}
+	if !private.PublicKey().Equal(public) {
+		return fmt.Errorf("%w: d does not match x, y", ErrInvalidJWK)
+	}
+	return nil
+}
+
+// ParseJWK: JSON 으로 직렬화된 JWK 파싱
+func ParseJWK(b []byte) (PrivateJWK, error) {
+	var jwk PrivateJWK
+	if err := json.Unmarshal(b, &jwk); err != nil {
+		return PrivateJWK{}, fmt.Errorf("%w: %v", ErrInvalidJWK, err)
+	}
+	if jwk.Kty == "" {
+		return PrivateJWK{}, fmt.Errorf("%w: missing kty", ErrInvalidJWK)
+	}
+	return jwk, nil
+}
+
+// PEMToJWK: PEM 키를 JWK 로 변환, 개인키는 개인키 멤버를 포함
+func PEMToJWK(b []byte, alg string) (PrivateJWK, error) {
+	key, err := ParsePEM(b)
+	if err != nil {
+		return PrivateJWK{}, err
+	}
+	if alg == "" {
+		alg = Alg(key)
+	}
+	if _, ok := key.(crypto.Signer); ok {
+		return NewPrivateJWK(key, alg)
+	}
+	public, err := NewPublicJWK(key, alg)
+	if err != nil {
+		return PrivateJWK{}, err
+	}
+	return PrivateJWK{JWK: public}, nil
+}
+
+// JWKToPEM: JWK 를 PEM 으로 변환, oct 키는 PEM 으로 표현할 수 없음
+func JWKToPEM(jwk PrivateJWK) ([]byte, error) {
+	if jwk.Kty == "oct" {
+		return nil, fmt.Errorf("%w: kty oct", ErrUnsupportedKey)
+	}
+	key, err := jwk.Key()
+	if err != nil {
+		return nil, err
+	}
+	return EncodePEM(key)
+}
+
+func encodeBigInt(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
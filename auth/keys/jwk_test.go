@@ -0,0 +1,155 @@
+package keys
+
+import (
+	"crypto"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivateJWK(t *testing.T) {
+	testCases := []struct {
+		alg string
+		kty string
+	}{
+		{alg: "RS256", kty: "RSA"},
+		{alg: "ES256", kty: "EC"},
+		{alg: "ES512", kty: "EC"},
+		{alg: "EdDSA", kty: "OKP"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.alg, func(t *testing.T) {
+			t.Parallel()
+			key, err := Generate(tc.alg)
+			require.NoError(t, err)
+
+			jwk, err := NewPrivateJWK(key, tc.alg)
+			require.NoError(t, err)
+			assert.Equal(t, tc.kty, jwk.Kty)
+			assert.Equal(t, tc.alg, jwk.Alg)
+			assert.True(t, jwk.IsPrivate())
+
+			// kid 는 공개 멤버의 RFC 7638 thumbprint
+			public, err := jwk.Public()
+			require.NoError(t, err)
+			thumbprint, err := public.Thumbprint()
+			require.NoError(t, err)
+			assert.Equal(t, thumbprint, jwk.Kid)
+
+			publicJWK, err := NewPublicJWK(key, tc.alg)
+			require.NoError(t, err)
+			assert.Equal(t, public, publicJWK)
+
+			// 공개 JWK 에는 개인키 멤버가 포함되지 않아야 함
+			b, err := json.Marshal(public)
+			require.NoError(t, err)
+			assert.NotContains(t, string(b), `"d"`)
+
+			// JSON 직렬화 후에도 같은 개인키로 복원되어야 함
+			b, err = json.Marshal(jwk)
+			require.NoError(t, err)
+			parsed, err := ParseJWK(b)
+			require.NoError(t, err)
+			restored, err := parsed.Key()
+			require.NoError(t, err)
+			assert.True(t, restored.(interface{ Equal(crypto.PrivateKey) bool }).Equal(key))
+		})
+	}
+}
+
+func TestSecretJWK(t *testing.T) {
+	secret, err := GenerateSecret(DefaultSecretSize)
+	require.NoError(t, err)
+
+	jwk, err := NewPrivateJWK(secret, "HS512")
+	require.NoError(t, err)
+	assert.Equal(t, "oct", jwk.Kty)
+	assert.NotEmpty(t, jwk.Kid)
+
+	key, err := jwk.Key()
+	require.NoError(t, err)
+	assert.Equal(t, secret, key)
+
+	_, err = jwk.Public()
+	assert.ErrorIs(t, err, ErrNoPublicKey)
+
+	_, err = JWKToPEM(jwk)
+	assert.ErrorIs(t, err, ErrUnsupportedKey)
+}
+
+func TestPEMJWKConversion(t *testing.T) {
+	key, err := Generate("ES384")
+	require.NoError(t, err)
+	signer := key.(crypto.Signer)
+
+	t.Run("개인키", func(t *testing.T) {
+		private, err := EncodePEM(signer)
+		require.NoError(t, err)
+
+		jwk, err := PEMToJWK(private, "")
+		require.NoError(t, err)
+		assert.Equal(t, "ES384", jwk.Alg)
+		assert.True(t, jwk.IsPrivate())
+
+		converted, err := JWKToPEM(jwk)
+		require.NoError(t, err)
+		assert.Equal(t, string(private), string(converted))
+	})
+
+	t.Run("공개키", func(t *testing.T) {
+		public, err := EncodePEM(signer.Public())
+		require.NoError(t, err)
+
+		jwk, err := PEMToJWK(public, "")
+		require.NoError(t, err)
+		assert.False(t, jwk.IsPrivate())
+
+		converted, err := JWKToPEM(jwk)
+		require.NoError(t, err)
+		assert.Equal(t, string(public), string(converted))
+	})
+}
+
+func TestParseJWKInvalid(t *testing.T) {
+	key, err := Generate("ES256")
+	require.NoError(t, err)
+	other, err := Generate("ES256")
+	require.NoError(t, err)
+
+	jwk, err := NewPrivateJWK(key, "ES256")
+	require.NoError(t, err)
+	otherJWK, err := NewPrivateJWK(other, "ES256")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		jwk  func() PrivateJWK
+	}{
+		{name: "공개키와 맞지 않는 d", jwk: func() PrivateJWK {
+			mismatched := jwk
+			mismatched.D = otherJWK.D
+			return mismatched
+		}},
+		{name: "잘못된 base64", jwk: func() PrivateJWK {
+			invalid := jwk
+			invalid.D = "!!"
+			return invalid
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.jwk().Key()
+			assert.ErrorIs(t, err, ErrInvalidJWK)
+		})
+	}
+
+	t.Run("kty 누락", func(t *testing.T) {
+		_, err := ParseJWK([]byte(`{"k":"c2VjcmV0"}`))
+		assert.ErrorIs(t, err, ErrInvalidJWK)
+	})
+}
@@ -0,0 +1,211 @@
+package keys
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	// MinSecretSize: HMAC secret 최소 길이 (RFC 7518 Section 3.2, HS256 의 해시 크기)
+	MinSecretSize = 32
+	// DefaultSecretSize: HS512 에도 사용할 수 있는 기본 HMAC secret 길이
+	DefaultSecretSize = 64
+	// MinRSABits: RSA 키 최소 크기 (RFC 7518 Section 3.3)
+	MinRSABits = 2048
+)
+
+var (
+	ErrUnsupportedKey   = errors.New("unsupported key type")
+	ErrUnsupportedCurve = errors.New("unsupported curve")
+	ErrKeyTooShort      = errors.New("key is too short")
+	ErrNoPEMBlock       = errors.New("no PEM block found")
+)
+
+// GenerateSecret: size 바이트의 HMAC secret 생성
+func GenerateSecret(size int) ([]byte, error) {
+	if size < MinSecretSize {
+		return nil, fmt.Errorf("%w: HMAC secret must be at least %d bytes, got %d", ErrKeyTooShort, MinSecretSize, size)
+	}
+	secret := make([]byte, size)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// GenerateRSA: bits 크기의 RSA 키 생성
+func GenerateRSA(bits int) (*rsa.PrivateKey, error) {
+	if bits < MinRSABits {
+		return nil, fmt.Errorf("%w: RSA key must be at least %d bits, got %d", ErrKeyTooShort, MinRSABits, bits)
+	}
+	return rsa.GenerateKey(rand.Reader, bits)
+}
+
+// GenerateEC: P-256, P-384, P-521 곡선의 ECDSA 키 생성
+func GenerateEC(curve string) (*ecdsa.PrivateKey, error) {
+	c, err := curveByName(curve)
+	if err != nil {
+		return nil, err
+	}
+	return ecdsa.GenerateKey(c, rand.Reader)
+}
+
+// GenerateEd25519: Ed25519 키 생성
+func GenerateEd25519() (ed25519.PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	return key, err
+}
+
+// Generate: JWS 알고리즘에 맞는 키 생성
+// HS* 는 해시 크기의 두 배인 secret ([]byte), 그 외는 crypto.Signer
+func Generate(alg string) (interface{}, error) {
+	switch alg {
+	case "HS256":
+		return GenerateSecret(64)
+	case "HS384":
+		return GenerateSecret(96)
+	case "HS512":
+		return GenerateSecret(128)
+	case "RS256", "PS256":
+		return GenerateRSA(2048)
+	case "RS384", "PS384":
+		return GenerateRSA(3072)
+	case "RS512", "PS512":
+		return GenerateRSA(4096)
+	case "ES256":
+		return GenerateEC("P-256")
+	case "ES384":
+		return GenerateEC("P-384")
+	case "ES512":
+		return GenerateEC("P-521")
+	case "EdDSA":
+		return GenerateEd25519()
+	default:
+		return nil, fmt.Errorf("%w: alg %s", ErrUnsupportedKey, alg)
+	}
+}
+
+// Alg: 키 종류에 맞는 기본 JWS 알고리즘
+func Alg(key interface{}) string {
+	switch k := key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return "RS256"
+	case *ecdsa.PrivateKey:
+		return ecdsaAlg(k.Curve)
+	case *ecdsa.PublicKey:
+		return ecdsaAlg(k.Curve)
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return "EdDSA"
+	case []byte:
+		return "HS256"
+	default:
+		return ""
+	}
+}
+
+func ecdsaAlg(curve elliptic.Curve) string {
+	switch curve.Params().BitSize {
+	case 384:
+		return "ES384"
+	case 521:
+		return "ES512"
+	default:
+		return "ES256"
+	}
+}
+
+// Public: 개인키의 공개키, 공개키는 그대로 반환
+func Public(key interface{}) (crypto.PublicKey, error) {
+	switch k := key.(type) {
+	case crypto.Signer:
+		return k.Public(), nil
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return k, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// EncodePEM: 개인키는 PKCS #8, 공개키는 PKIX PEM 으로 인코딩
+func EncodePEM(key interface{}) ([]byte, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+		der, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// ParsePEM: 첫 번째 PEM 블록의 키 파싱
+// PKCS #8, PKCS #1, SEC 1 개인키와 PKIX, PKCS #1 공개키, X.509 인증서를 지원
+func ParsePEM(b []byte) (interface{}, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, ErrNoPEMBlock
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("%w: PEM block %q", ErrUnsupportedKey, block.Type)
+	}
+}
+
+// ParseKey: PEM 또는 JSON 으로 직렬화된 JWK 키 파싱
+func ParseKey(b []byte) (interface{}, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		jwk, err := ParseJWK(b)
+		if err != nil {
+			return nil, err
+		}
+		return jwk.Key()
+	}
+	return ParsePEM(b)
+}
+
+func curveByName(name string) (elliptic.Curve, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurve, name)
+	}
+}
@@ -0,0 +1,109 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		alg    string
+		assert func(t *testing.T, key interface{})
+	}{
+		{alg: "HS256", assert: func(t *testing.T, key interface{}) { assert.Len(t, key, 64) }},
+		{alg: "HS512", assert: func(t *testing.T, key interface{}) { assert.Len(t, key, 128) }},
+		{alg: "RS256", assert: func(t *testing.T, key interface{}) { assert.Equal(t, 2048, key.(*rsa.PrivateKey).N.BitLen()) }},
+		{alg: "PS384", assert: func(t *testing.T, key interface{}) { assert.Equal(t, 3072, key.(*rsa.PrivateKey).N.BitLen()) }},
+		{alg: "ES256", assert: func(t *testing.T, key interface{}) {
+			assert.Equal(t, "P-256", key.(*ecdsa.PrivateKey).Curve.Params().Name)
+		}},
+		{alg: "ES384", assert: func(t *testing.T, key interface{}) {
+			assert.Equal(t, "P-384", key.(*ecdsa.PrivateKey).Curve.Params().Name)
+		}},
+		{alg: "ES512", assert: func(t *testing.T, key interface{}) {
+			assert.Equal(t, "P-521", key.(*ecdsa.PrivateKey).Curve.Params().Name)
+		}},
+		{alg: "EdDSA", assert: func(t *testing.T, key interface{}) { assert.IsType(t, ed25519.PrivateKey{}, key) }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.alg, func(t *testing.T) {
+			t.Parallel()
+			key, err := Generate(tc.alg)
+			require.NoError(t, err)
+			tc.assert(t, key)
+
+			// 생성한 키로 해당 알고리즘의 서명, 검증이 가능해야 함
+			method := jwt.GetSigningMethod(tc.alg)
+			verifyKey := key
+			if signer, ok := key.(crypto.Signer); ok {
+				verifyKey = signer.Public()
+			}
+			signature, err := method.Sign("header.payload", key)
+			require.NoError(t, err)
+			assert.NoError(t, method.Verify("header.payload", signature, verifyKey))
+		})
+	}
+
+	t.Run("지원하지 않는 알고리즘", func(t *testing.T) {
+		_, err := Generate("none")
+		assert.ErrorIs(t, err, ErrUnsupportedKey)
+	})
+}
+
+func TestGenerateRejectsWeakKeys(t *testing.T) {
+	_, err := GenerateSecret(MinSecretSize - 1)
+	assert.ErrorIs(t, err, ErrKeyTooShort)
+
+	_, err = GenerateRSA(1024)
+	assert.ErrorIs(t, err, ErrKeyTooShort)
+
+	_, err = GenerateEC("P-224")
+	assert.ErrorIs(t, err, ErrUnsupportedCurve)
+}
+
+func TestPEM(t *testing.T) {
+	for _, alg := range []string{"RS256", "ES384", "EdDSA"} {
+		alg := alg
+		t.Run(alg, func(t *testing.T) {
+			t.Parallel()
+			key, err := Generate(alg)
+			require.NoError(t, err)
+			signer := key.(crypto.Signer)
+
+			private, err := EncodePEM(signer)
+			require.NoError(t, err)
+			assert.Contains(t, string(private), "BEGIN PRIVATE KEY")
+			parsed, err := ParsePEM(private)
+			require.NoError(t, err)
+			assert.True(t, parsed.(interface{ Equal(crypto.PrivateKey) bool }).Equal(signer))
+
+			public, err := EncodePEM(signer.Public())
+			require.NoError(t, err)
+			assert.Contains(t, string(public), "BEGIN PUBLIC KEY")
+			parsed, err = ParsePEM(public)
+			require.NoError(t, err)
+			assert.True(t, parsed.(interface{ Equal(crypto.PublicKey) bool }).Equal(signer.Public()))
+
+			assert.Equal(t, alg, Alg(parsed))
+		})
+	}
+
+	t.Run("PEM 이 아닌 입력", func(t *testing.T) {
+		_, err := ParsePEM([]byte("secret"))
+		assert.ErrorIs(t, err, ErrNoPEMBlock)
+	})
+
+	t.Run("HMAC secret 은 PEM 으로 인코딩 불가", func(t *testing.T) {
+		_, err := EncodePEM([]byte("secret"))
+		assert.ErrorIs(t, err, ErrUnsupportedKey)
+	})
+}
@@ -0,0 +1,60 @@
+package keys
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+var ErrSignerKeyChanged = errors.New("signer key no longer matches its public key")
+
+// FileSigner: 서명할 때마다 키 파일을 읽는 crypto.Signer
+// KMS, HSM 처럼 개인키를 프로세스에 보관하지 않는 원격 서명자를 로컬에서 대신하기 위한 구현
+// 공개키만 메모리에 유지하며, 파일의 키가 바뀌면 서명을 거부
+type FileSigner struct {
+	path   string
+	public crypto.PublicKey
+}
+
+// NewFileSigner: PEM 또는 JWK 개인키 파일로 FileSigner 생성
+func NewFileSigner(path string) (*FileSigner, error) {
+	signer, err := loadSigner(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSigner{path: path, public: signer.Public()}, nil
+}
+
+func (s *FileSigner) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign: 키 파일을 다시 읽어 서명하고, 사용한 키는 보관하지 않음
+func (s *FileSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	signer, err := loadSigner(s.path)
+	if err != nil {
+		return nil, err
+	}
+	if !s.public.(interface{ Equal(crypto.PublicKey) bool }).Equal(signer.Public()) {
+		return nil, ErrSignerKeyChanged
+	}
+	return signer.Sign(rand, digest, opts)
+}
+
+func loadSigner(path string) (crypto.Signer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ParseKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: a private key is required", path, ErrUnsupportedKey)
+	}
+	return signer, nil
+}
@@ -0,0 +1,57 @@
+package keys
+
+import (
+	"crypto"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSigner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "private.pem")
+	writeKey := func() {
+		key, err := Generate("ES256")
+		require.NoError(t, err)
+		b, err := EncodePEM(key)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, b, 0o600))
+	}
+	writeKey()
+
+	signer, err := NewFileSigner(path)
+	require.NoError(t, err)
+	config, err := v4jwt.NewSignerConfig(jwt.SigningMethodES256, signer)
+	require.NoError(t, err)
+	creator := v4jwt.NewCreator(config)
+	validator := v4jwt.NewValidator[*jwt.RegisteredClaims](v4jwt.NewKeyPairConfig(jwt.SigningMethodES256, nil, signer.Public()))
+
+	claims := &jwt.RegisteredClaims{Subject: "user-1", ExpiresAt: jwt.NewNumericDate(jwt.TimeFunc().Add(time.Minute))}
+	token, err := creator.CreateToken(claims)
+	require.NoError(t, err)
+	_, err = validator.ValidateToken(token, &jwt.RegisteredClaims{})
+	assert.NoError(t, err)
+
+	t.Run("키 파일이 바뀌면 서명 거부", func(t *testing.T) {
+		writeKey()
+		_, err := creator.CreateToken(claims)
+		assert.ErrorIs(t, err, ErrSignerKeyChanged)
+	})
+
+	t.Run("공개키 파일", func(t *testing.T) {
+		key, err := Generate("ES256")
+		require.NoError(t, err)
+		b, err := EncodePEM(key.(crypto.Signer).Public())
+		require.NoError(t, err)
+		publicPath := filepath.Join(t.TempDir(), "public.pem")
+		require.NoError(t, os.WriteFile(publicPath, b, 0o644))
+
+		_, err = NewFileSigner(publicPath)
+		assert.ErrorIs(t, err, ErrUnsupportedKey)
+	})
+}
@@ -0,0 +1,153 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+// ClientAssertionType: private_key_jwt 의 client_assertion_type (RFC 7523 Section 2.2)
+const ClientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+const defaultAssertionMaxLifetime = 5 * time.Minute
+
+var (
+	ErrAssertionInvalid  = errors.New("invalid client assertion")
+	ErrAssertionReplayed = errors.New("client assertion has already been used")
+)
+
+// client assertion 서명에 허용하는 비대칭 알고리즘
+var assertionAlgs = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// ClientAssertionVerifier: private_key_jwt 클라이언트 인증 (RFC 7523 Section 3)
+// 클라이언트의 Keys 에 등록된 공개키로 서명을 검증
+type ClientAssertionVerifier struct {
+	clients     ClientStore
+	audience    string
+	maxLifetime time.Duration
+	now         func() time.Time
+	replay      v4jwt.ReplayCache
+}
+
+type ClientAssertionOption func(*ClientAssertionVerifier)
+
+// WithAssertionMaxLifetime: exp 가 현재 시각으로부터 허용하는 최대 시간
+func WithAssertionMaxLifetime(maxLifetime time.Duration) ClientAssertionOption {
+	return func(v *ClientAssertionVerifier) {
+		v.maxLifetime = maxLifetime
+	}
+}
+
+// WithAssertionReplayCache: assertion 의 jti 를 기록할 ReplayCache
+func WithAssertionReplayCache(cache v4jwt.ReplayCache) ClientAssertionOption {
+	return func(v *ClientAssertionVerifier) {
+		v.replay = cache
+	}
+}
+
+// WithClientAssertionOptions: 토큰 엔드포인트의 client assertion 검증 옵션
+// 다중 인스턴스 환경에서는 WithAssertionReplayCache 로 공유 ReplayCache 를 설정해야 인스턴스 간 재사용을 막을 수 있음
+func WithClientAssertionOptions(opts ...ClientAssertionOption) ServerOption {
+	return func(s *Server) {
+		s.assertionOptions = append(s.assertionOptions, opts...)
+	}
+}
+
+// NewClientAssertionVerifier: audience 는 토큰 엔드포인트 URL
+func NewClientAssertionVerifier(clients ClientStore, audience string, opts ...ClientAssertionOption) *ClientAssertionVerifier {
+	v := &ClientAssertionVerifier{
+		clients:     clients,
+		audience:    audience,
+		maxLifetime: defaultAssertionMaxLifetime,
+		now:         time.Now,
+		replay:      v4jwt.NewMemoryReplayCache(),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Verify: client assertion 을 검증하고 인증된 클라이언트 반환
+func (v *ClientAssertionVerifier) Verify(ctx context.Context, assertion string) (*Client, error) {
+	var client *Client
+	claims := &jwt.RegisteredClaims{}
+
+	parser := jwt.NewParser(jwt.WithValidMethods(assertionAlgs))
+	_, err := parser.ParseWithClaims(assertion, claims, func(token *jwt.Token) (interface{}, error) {
+		c := token.Claims.(*jwt.RegisteredClaims)
+		if c.Issuer == "" || c.Issuer != c.Subject {
+			return nil, fmt.Errorf("%w: iss and sub must be the client_id", ErrAssertionInvalid)
+		}
+
+		var err error
+		client, err = v.clients.GetClient(ctx, c.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		if client.Keys == nil {
+			return nil, fmt.Errorf("%w: client has no registered keys", ErrAssertionInvalid)
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		jwk, err := client.Keys.Key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		return jwk.PublicKey()
+	})
+	if err != nil {
+		if errors.Is(err, ErrAssertionInvalid) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrAssertionInvalid, err)
+	}
+
+	if !claims.VerifyAudience(v.audience, true) {
+		return nil, fmt.Errorf("%w: aud must be the token endpoint", ErrAssertionInvalid)
+	}
+
+	now := v.now()
+	if claims.ExpiresAt == nil || !now.Before(claims.ExpiresAt.Time) {
+		return nil, fmt.Errorf("%w: exp is required", ErrAssertionInvalid)
+	}
+	if claims.ExpiresAt.Time.After(now.Add(v.maxLifetime)) {
+		return nil, fmt.Errorf("%w: exp is too far in the future", ErrAssertionInvalid)
+	}
+
+	if claims.ID == "" {
+		return nil, fmt.Errorf("%w: jti is required", ErrAssertionInvalid)
+	}
+	ok, err := v.replay.Use(ctx, client.ID+":"+claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrAssertionReplayed
+	}
+
+	return client, nil
+}
+
+// CreateClientAssertion: 외부 인가 서버의 토큰 엔드포인트에 보낼 client assertion 생성
+// creator 는 인가 서버에 등록한 공개키와 짝이 되는 개인키, kid 로 설정
+func CreateClientAssertion(creator *v4jwt.Creator, clientID, tokenEndpoint string, ttl time.Duration) (string, error) {
+	jti, err := v4jwt.NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	return creator.CreateToken(&jwt.RegisteredClaims{
+		Issuer:    clientID,
+		Subject:   clientID,
+		Audience:  jwt.ClaimStrings{tokenEndpoint},
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ID:        jti,
+	})
+}
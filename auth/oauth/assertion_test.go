@@ -0,0 +1,174 @@
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTokenEndpoint = "https://auth.example.com/token"
+
+func newAssertionClient(t *testing.T, clientID string) (*Client, *v4jwt.Creator) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	config := v4jwt.NewKeyPairConfig(jwt.SigningMethodES256, key, &key.PublicKey, v4jwt.WithKeyID(clientID+"-key"))
+	jwks, err := v4jwt.NewJWKS(config)
+	require.NoError(t, err)
+
+	client := &Client{
+		ID:           clientID,
+		RedirectURIs: []string{testRedirectURI},
+		Scopes:       []string{"read"},
+		Keys:         jwks,
+	}
+	return client, v4jwt.NewCreator(config)
+}
+
+func TestClientAssertionVerifier(t *testing.T) {
+	client, creator := newAssertionClient(t, "backend")
+	_, otherCreator := newAssertionClient(t, "backend")
+	verifier := NewClientAssertionVerifier(NewMemoryClientStore(client), testTokenEndpoint)
+
+	sign := func(creator *v4jwt.Creator, claims jwt.RegisteredClaims) string {
+		token, err := creator.CreateToken(&claims)
+		require.NoError(t, err)
+		return token
+	}
+
+	validClaims := func() jwt.RegisteredClaims {
+		return jwt.RegisteredClaims{
+			Issuer:    "backend",
+			Subject:   "backend",
+			Audience:  jwt.ClaimStrings{testTokenEndpoint},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			ID:        time.Now().String(),
+		}
+	}
+
+	t.Run("CreateClientAssertion 으로 만든 assertion 검증 성공", func(t *testing.T) {
+		assertion, err := CreateClientAssertion(creator, "backend", testTokenEndpoint, time.Minute)
+		require.NoError(t, err)
+
+		got, err := verifier.Verify(context.Background(), assertion)
+		require.NoError(t, err)
+		assert.Equal(t, "backend", got.ID)
+
+		// 같은 jti 재사용 불가
+		_, err = verifier.Verify(context.Background(), assertion)
+		assert.ErrorIs(t, err, ErrAssertionReplayed)
+	})
+
+	testCases := []struct {
+		name    string
+		creator *v4jwt.Creator
+		modify  func(*jwt.RegisteredClaims)
+	}{
+		{name: "iss 와 sub 가 다른 경우", creator: creator, modify: func(c *jwt.RegisteredClaims) { c.Subject = "other" }},
+		{name: "aud 가 토큰 엔드포인트가 아닌 경우", creator: creator, modify: func(c *jwt.RegisteredClaims) { c.Audience = jwt.ClaimStrings{"https://auth.example.com"} }},
+		{name: "exp 가 없는 경우", creator: creator, modify: func(c *jwt.RegisteredClaims) { c.ExpiresAt = nil }},
+		{name: "exp 가 너무 긴 경우", creator: creator, modify: func(c *jwt.RegisteredClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Hour)) }},
+		{name: "jti 가 없는 경우", creator: creator, modify: func(c *jwt.RegisteredClaims) { c.ID = "" }},
+		{name: "등록되지 않은 키로 서명한 경우", creator: otherCreator, modify: func(c *jwt.RegisteredClaims) {}},
+		{name: "등록되지 않은 클라이언트", creator: creator, modify: func(c *jwt.RegisteredClaims) { c.Issuer, c.Subject = "unknown", "unknown" }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			claims := validClaims()
+			tc.modify(&claims)
+
+			_, err := verifier.Verify(context.Background(), sign(tc.creator, claims))
+			assert.ErrorIs(t, err, ErrAssertionInvalid)
+		})
+	}
+}
+
+func TestTokenEndpointPrivateKeyJWT(t *testing.T) {
+	client, creator := newAssertionClient(t, "backend")
+	s := newTestServer()
+	s.clients.(*MemoryClientStore).AddClient(client)
+
+	params := defaultAuthorizeParams()
+	params.Set("client_id", "backend")
+	w := httptest.NewRecorder()
+	s.HandleAuthorize(w, authorizeRequest(params))
+	require.Equal(t, http.StatusFound, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {location.Query().Get("code")},
+		"redirect_uri":  {testRedirectURI},
+		"code_verifier": {testVerifier},
+	}
+
+	t.Run("assertion 없이 client_id 만 전달하면 거부", func(t *testing.T) {
+		f := url.Values{"grant_type": {"authorization_code"}, "client_id": {"backend"}}
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(f))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), ErrorInvalidClient)
+	})
+
+	t.Run("private_key_jwt 로 인증", func(t *testing.T) {
+		assertion, err := CreateClientAssertion(creator, "backend", s.tokenEndpoint(), time.Minute)
+		require.NoError(t, err)
+
+		form.Set("client_assertion_type", ClientAssertionType)
+		form.Set("client_assertion", assertion)
+
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(form))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestServerSharedAssertionReplayCache(t *testing.T) {
+	client, creator := newAssertionClient(t, "backend")
+	cache := v4jwt.NewMemoryReplayCache()
+	servers := []*Server{
+		newTestServer(WithClientAssertionOptions(WithAssertionReplayCache(cache))),
+		newTestServer(WithClientAssertionOptions(WithAssertionReplayCache(cache))),
+	}
+	for _, s := range servers {
+		s.clients.(*MemoryClientStore).AddClient(client)
+	}
+
+	assertion, err := CreateClientAssertion(creator, "backend", servers[0].tokenEndpoint(), time.Minute)
+	require.NoError(t, err)
+	form := url.Values{
+		"grant_type":            {"authorization_code"},
+		"code":                  {"unknown"},
+		"redirect_uri":          {testRedirectURI},
+		"code_verifier":         {testVerifier},
+		"client_assertion_type": {ClientAssertionType},
+		"client_assertion":      {assertion},
+	}
+
+	// 첫 번째 인스턴스는 클라이언트 인증 후 코드를 거부
+	w := httptest.NewRecorder()
+	servers[0].HandleToken(w, tokenRequest(form))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrorInvalidGrant)
+
+	// 다른 인스턴스에서도 같은 assertion 은 재사용으로 거부
+	w = httptest.NewRecorder()
+	servers[1].HandleToken(w, tokenRequest(form))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), ErrorInvalidClient)
+}
@@ -0,0 +1,108 @@
+package oauth
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// HandleAuthorize: 인가 엔드포인트 (RFC 6749 Section 4.1.1, RFC 7636 Section 4.3)
+// response_type=code 와 PKCE(S256) 만 지원
+func (s *Server) HandleAuthorize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		writeError(w, newError(http.StatusMethodNotAllowed, ErrorInvalidRequest, "method not allowed"))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, newError(http.StatusBadRequest, ErrorInvalidRequest, "malformed request"))
+		return
+	}
+
+	// client_id, redirect_uri 가 검증되기 전에는 리다이렉트 하지 않음 (open redirector 방지)
+	clientID := r.Form.Get("client_id")
+	if clientID == "" {
+		writeError(w, newError(http.StatusBadRequest, ErrorInvalidRequest, "client_id is required"))
+		return
+	}
+
+	client, err := s.clients.GetClient(r.Context(), clientID)
+	if err != nil {
+		writeError(w, newError(http.StatusBadRequest, ErrorInvalidClient, "unknown client"))
+		return
+	}
+
+	redirectURI := r.Form.Get("redirect_uri")
+	if redirectURI == "" || !client.ValidRedirectURI(redirectURI) {
+		writeError(w, newError(http.StatusBadRequest, ErrorInvalidRequest, "redirect_uri does not match a registered uri"))
+		return
+	}
+
+	state := r.Form.Get("state")
+
+	if r.Form.Get("response_type") != "code" {
+		redirectError(w, r, redirectURI, state, newError(0, ErrorUnsupportedResponseType, "only response_type=code is supported"))
+		return
+	}
+
+	challenge := r.Form.Get("code_challenge")
+	if r.Form.Get("code_challenge_method") != CodeChallengeMethodS256 || !validChallenge(challenge) {
+		redirectError(w, r, redirectURI, state, newError(0, ErrorInvalidRequest, "code_challenge with code_challenge_method=S256 is required"))
+		return
+	}
+
+	scopes := splitScope(r.Form.Get("scope"))
+	if !client.AllowedScopes(scopes) {
+		redirectError(w, r, redirectURI, state, newError(0, ErrorInvalidScope, "requested scope is not allowed"))
+		return
+	}
+
+	auth, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	granted, ok := s.consent(w, r, client, auth, scopes)
+	if !ok {
+		return
+	}
+	// 동의 훅이 요청되지 않은 scope 를 반환해도 요청되고 클라이언트에 허용된 scope 만 발급
+	granted = intersectScopes(granted, scopes)
+
+	code, err := randomString(32)
+	if err != nil {
+		redirectError(w, r, redirectURI, state, newError(0, ErrorServerError, ""))
+		return
+	}
+
+	authTime := auth.AuthTime
+	if authTime.IsZero() {
+		authTime = s.now()
+	}
+
+	err = s.codes.Save(r.Context(), &AuthorizationCode{
+		Code:          code,
+		ClientID:      client.ID,
+		RedirectURI:   redirectURI,
+		Subject:       auth.Subject,
+		Scopes:        granted,
+		CodeChallenge: challenge,
+		Nonce:         r.Form.Get("nonce"),
+		AuthTime:      authTime,
+		ExpiresAt:     s.now().Add(s.codeTTL),
+	})
+	if err != nil {
+		redirectError(w, r, redirectURI, state, newError(0, ErrorServerError, ""))
+		return
+	}
+
+	u, _ := url.Parse(redirectURI)
+	q := u.Query()
+	q.Set("code", code)
+	if state != "" {
+		q.Set("state", state)
+	}
+	u.RawQuery = q.Encode()
+
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
@@ -0,0 +1,97 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+var (
+	ErrClientNotFound = errors.New("client not found")
+)
+
+// Client: 인가 서버에 등록된 클라이언트 (SPA, 모바일 앱 등)
+// Secret, Keys 가 모두 비어있으면 public client 로 취급하고 PKCE 만으로 보호
+type Client struct {
+	ID           string
+	Secret       string
+	RedirectURIs []string
+	Scopes       []string
+	// Keys: private_key_jwt 인증에 사용하는 클라이언트 공개키 (등록된 JWKS 또는 jwks_uri)
+	Keys v4jwt.KeySet
+}
+
+// Public: client secret, 공개키가 없는 public client 여부
+func (c *Client) Public() bool {
+	return c.Secret == "" && c.Keys == nil
+}
+
+// ValidRedirectURI: 등록된 redirect_uri 와 정확히 일치하는지 확인
+// prefix, 와일드카드 매칭은 허용하지 않음
+func (c *Client) ValidRedirectURI(redirectURI string) bool {
+	for _, uri := range c.RedirectURIs {
+		if uri == redirectURI {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedScopes: 요청된 scope 가 모두 등록된 scope 안에 포함되는지 확인
+func (c *Client) AllowedScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// ClientStore: 클라이언트 조회 인터페이스
+type ClientStore interface {
+	GetClient(ctx context.Context, clientID string) (*Client, error)
+}
+
+// MemoryClientStore: 메모리 기반 ClientStore 구현체
+type MemoryClientStore struct {
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+func NewMemoryClientStore(clients ...*Client) *MemoryClientStore {
+	s := &MemoryClientStore{
+		clients: make(map[string]*Client),
+	}
+	for _, c := range clients {
+		s.clients[c.ID] = c
+	}
+	return s
+}
+
+func (s *MemoryClientStore) AddClient(client *Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clients[client.ID] = client
+}
+
+func (s *MemoryClientStore) GetClient(_ context.Context, clientID string) (*Client, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	client, ok := s.clients[clientID]
+	if !ok {
+		return nil, ErrClientNotFound
+	}
+	return client, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
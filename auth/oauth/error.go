@@ -0,0 +1,90 @@
+package oauth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// RFC 6749 Section 4.1.2.1, 5.2 에 정의된 에러 코드
+const (
+	ErrorInvalidRequest          = "invalid_request"
+	ErrorInvalidClient           = "invalid_client"
+	ErrorInvalidGrant            = "invalid_grant"
+	ErrorUnauthorizedClient      = "unauthorized_client"
+	ErrorUnsupportedGrantType    = "unsupported_grant_type"
+	ErrorUnsupportedResponseType = "unsupported_response_type"
+	ErrorInvalidScope            = "invalid_scope"
+	ErrorAccessDenied            = "access_denied"
+	ErrorServerError             = "server_error"
+)
+
+// Error: OAuth 2.0 에러 응답
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	status      int
+}
+
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+func newError(status int, code, description string) *Error {
+	return &Error{
+		Code:        code,
+		Description: description,
+		status:      status,
+	}
+}
+
+// writeError: 토큰 엔드포인트 형식의 JSON 에러 응답 작성
+func writeError(w http.ResponseWriter, err error) {
+	var oauthErr *Error
+	if !errors.As(err, &oauthErr) {
+		oauthErr = newError(http.StatusInternalServerError, ErrorServerError, "")
+	}
+
+	status := oauthErr.status
+	if status == 0 {
+		status = http.StatusBadRequest
+	}
+
+	if oauthErr.Code == ErrorInvalidClient {
+		w.Header().Set("WWW-Authenticate", `Basic realm="token"`)
+	}
+	writeJSON(w, status, oauthErr)
+}
+
+// redirectError: redirect_uri 가 검증된 이후의 에러는 클라이언트로 리다이렉트하여 전달
+func redirectError(w http.ResponseWriter, r *http.Request, redirectURI, state string, err *Error) {
+	u, parseErr := url.Parse(redirectURI)
+	if parseErr != nil {
+		writeError(w, err)
+		return
+	}
+
+	q := u.Query()
+	q.Set("error", err.Code)
+	if err.Description != "" {
+		q.Set("error_description", err.Description)
+	}
+	if state != "" {
+		q.Set("state", state)
+	}
+	u.RawQuery = q.Encode()
+
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,261 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+// RFC 8693 Section 2.1, 3
+const (
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+
+	ErrorInvalidTarget = "invalid_target"
+)
+
+const defaultExchangeTTL = 5 * time.Minute
+
+// ActClaim: 위임 관계를 나타내는 act 클레임 (RFC 8693 Section 4.1)
+// 가장 바깥쪽이 현재 actor 이며, 이전 actor 는 중첩된 Act 로 표현
+type ActClaim struct {
+	Subject string    `json:"sub"`
+	Act     *ActClaim `json:"act,omitempty"`
+}
+
+// ExchangeRequest: 토큰 교환 요청
+type ExchangeRequest struct {
+	// Client: 교환을 요청한 (인증된) 클라이언트
+	Client           *Client
+	SubjectToken     string
+	SubjectTokenType string
+	// ActorToken: 위임 (delegation) 의 경우에만 설정, 비어있으면 impersonation
+	ActorToken     string
+	ActorTokenType string
+	Audience       []string
+	Scopes         []string
+}
+
+// ExchangePolicy: 교환 허용 여부를 결정하는 정책
+// actor 는 ActorToken 이 없는 경우 nil
+type ExchangePolicy interface {
+	Authorize(ctx context.Context, req *ExchangeRequest, subject, actor *TokenClaims) error
+}
+
+// StaticExchangePolicy: audience 별 허용 scope 목록으로 교환을 제한하는 정책
+type StaticExchangePolicy struct {
+	// Audiences: 교환 가능한 audience 와 audience 별 허용 scope
+	Audiences map[string][]string
+	// Clients: 교환을 요청할 수 있는 client_id, 비어있으면 모든 클라이언트 거부
+	Clients []string
+	// Delegation: actor_token 을 이용한 위임 허용 여부
+	Delegation bool
+}
+
+func (p *StaticExchangePolicy) Authorize(_ context.Context, req *ExchangeRequest, _, actor *TokenClaims) error {
+	if req.Client == nil || !contains(p.Clients, req.Client.ID) {
+		return newError(http.StatusBadRequest, ErrorUnauthorizedClient, "client is not allowed to exchange tokens")
+	}
+
+	if actor != nil && !p.Delegation {
+		return newError(http.StatusBadRequest, ErrorInvalidRequest, "delegation is not allowed")
+	}
+
+	if len(req.Audience) == 0 {
+		return newError(http.StatusBadRequest, ErrorInvalidTarget, "audience is required")
+	}
+
+	for _, aud := range req.Audience {
+		allowed, ok := p.Audiences[aud]
+		if !ok {
+			return newError(http.StatusBadRequest, ErrorInvalidTarget, "audience is not allowed")
+		}
+		for _, scope := range req.Scopes {
+			if !contains(allowed, scope) {
+				return newError(http.StatusBadRequest, ErrorInvalidScope, "scope is not allowed for the audience")
+			}
+		}
+	}
+
+	return nil
+}
+
+// TokenExchanger: subject_token (와 actor_token) 을 검증하고 downscope 된 새 토큰을 발급
+type TokenExchanger struct {
+	validator v4jwt.TokenValidator[*TokenClaims]
+	creator   v4jwt.TokenCreator
+	policy    ExchangePolicy
+	issuer    string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+type ExchangeOption func(*TokenExchanger)
+
+// WithExchangeIssuer: 발급하는 토큰의 iss 클레임
+func WithExchangeIssuer(issuer string) ExchangeOption {
+	return func(e *TokenExchanger) {
+		e.issuer = issuer
+	}
+}
+
+// WithExchangeTTL: 발급하는 토큰의 유효 시간, subject_token 의 만료 시각을 넘지 않음
+func WithExchangeTTL(ttl time.Duration) ExchangeOption {
+	return func(e *TokenExchanger) {
+		e.ttl = ttl
+	}
+}
+
+// NewTokenExchanger: creator 는 발급하는 토큰의 typ 을 at+jwt 로 설정하기 위해 v4jwt.TypedTokenCreator 여야 함
+func NewTokenExchanger(validator v4jwt.TokenValidator[*TokenClaims], creator v4jwt.TokenCreator, policy ExchangePolicy, opts ...ExchangeOption) *TokenExchanger {
+	e := &TokenExchanger{
+		validator: validator,
+		creator:   creator,
+		policy:    policy,
+		ttl:       defaultExchangeTTL,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Exchange: 토큰 교환 (RFC 8693 Section 2)
+// 반환하는 에러는 *Error 이며 토큰 엔드포인트 응답으로 그대로 사용 가능
+func (e *TokenExchanger) Exchange(ctx context.Context, req *ExchangeRequest) (*TokenResponse, error) {
+	if req.SubjectToken == "" || !supportedTokenType(req.SubjectTokenType) {
+		return nil, newError(http.StatusBadRequest, ErrorInvalidRequest, "subject_token and a supported subject_token_type are required")
+	}
+
+	subject, err := e.validateToken(req.SubjectToken)
+	if err != nil {
+		return nil, newError(http.StatusBadRequest, ErrorInvalidGrant, "subject_token is invalid")
+	}
+
+	var actor *TokenClaims
+	if req.ActorToken != "" {
+		if !supportedTokenType(req.ActorTokenType) {
+			return nil, newError(http.StatusBadRequest, ErrorInvalidRequest, "unsupported actor_token_type")
+		}
+		actor, err = e.validateToken(req.ActorToken)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, ErrorInvalidGrant, "actor_token is invalid")
+		}
+	}
+
+	// scope 는 subject_token 이 가진 범위 안에서 축소만 가능
+	subjectScopes := splitScope(subject.Scope)
+	if len(req.Scopes) == 0 {
+		req.Scopes = subjectScopes
+	}
+	for _, scope := range req.Scopes {
+		if !contains(subjectScopes, scope) {
+			return nil, newError(http.StatusBadRequest, ErrorInvalidScope, "requested scope exceeds the subject_token")
+		}
+	}
+
+	if err := e.policy.Authorize(ctx, req, subject, actor); err != nil {
+		return nil, err
+	}
+
+	now := e.now()
+	expiresAt := now.Add(e.ttl)
+	if subject.ExpiresAt != nil && subject.ExpiresAt.Time.Before(expiresAt) {
+		expiresAt = subject.ExpiresAt.Time
+	}
+
+	jti, err := v4jwt.NewTokenID()
+	if err != nil {
+		return nil, newError(http.StatusInternalServerError, ErrorServerError, "")
+	}
+
+	claims := &TokenClaims{
+		ClientID: subject.ClientID,
+		Scope:    joinScope(req.Scopes),
+		TokenUse: TokenUseAccess,
+		Act:      subject.Act,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    e.issuer,
+			Subject:   subject.Subject,
+			Audience:  req.Audience,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ID:        jti,
+		},
+	}
+	if req.Client != nil {
+		claims.ClientID = req.Client.ID
+	}
+	// 위임의 경우 현재 actor 를 가장 바깥에 두고 기존 위임 체인을 중첩
+	if actor != nil {
+		claims.Act = &ActClaim{Subject: actor.Subject, Act: subject.Act}
+	}
+
+	token, err := createTypedToken(e.creator, claims, v4jwt.AccessTokenType)
+	if err != nil {
+		return nil, newError(http.StatusInternalServerError, ErrorServerError, "")
+	}
+
+	return &TokenResponse{
+		AccessToken:     token,
+		IssuedTokenType: TokenTypeAccessToken,
+		TokenType:       "Bearer",
+		ExpiresIn:       int64(expiresAt.Sub(now) / time.Second),
+		Scope:           claims.Scope,
+	}, nil
+}
+
+func (e *TokenExchanger) validateToken(token string) (*TokenClaims, error) {
+	claims, err := e.validator.ValidateToken(token, &TokenClaims{})
+	if err != nil {
+		return nil, err
+	}
+	// access 토큰만 교환 대상, refresh, ID 토큰과 token_use 가 없는 토큰은 거부
+	if claims.TokenUse != TokenUseAccess {
+		return nil, v4jwt.ErrTokenInvalidClaims
+	}
+	return claims, nil
+}
+
+func supportedTokenType(tokenType string) bool {
+	return tokenType == TokenTypeAccessToken || tokenType == TokenTypeJWT
+}
+
+// WithTokenExchange: 토큰 엔드포인트에서 token-exchange grant 허용
+// subject_token, actor_token 은 서버의 TokenManager 로 검증
+func WithTokenExchange(policy ExchangePolicy) ServerOption {
+	return func(s *Server) {
+		s.exchangePolicy = policy
+	}
+}
+
+func (s *Server) exchangeToken(r *http.Request, client *Client) (*TokenResponse, error) {
+	if s.exchanger == nil {
+		return nil, newError(http.StatusBadRequest, ErrorUnsupportedGrantType, "")
+	}
+
+	var scopes []string
+	if scope := r.PostForm.Get("scope"); scope != "" {
+		scopes = splitScope(scope)
+	}
+
+	audience := r.PostForm["audience"]
+	audience = append(audience, r.PostForm["resource"]...)
+
+	return s.exchanger.Exchange(r.Context(), &ExchangeRequest{
+		Client:           client,
+		SubjectToken:     r.PostForm.Get("subject_token"),
+		SubjectTokenType: r.PostForm.Get("subject_token_type"),
+		ActorToken:       r.PostForm.Get("actor_token"),
+		ActorTokenType:   r.PostForm.Get("actor_token_type"),
+		Audience:         audience,
+		Scopes:           scopes,
+	})
+}
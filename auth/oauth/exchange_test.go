@@ -0,0 +1,299 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExchangeTestServer() *Server {
+	return newTestServer(WithTokenExchange(&StaticExchangePolicy{
+		Audiences: map[string][]string{
+			"https://orders.example.com": {"read"},
+		},
+		Clients:    []string{"spa"},
+		Delegation: true,
+	}))
+}
+
+func createTestToken(t *testing.T, s *Server, subject, scope string, act *ActClaim) string {
+	t.Helper()
+	now := time.Now()
+	token, err := s.tokens.CreateToken(&TokenClaims{
+		ClientID: "spa",
+		Scope:    scope,
+		TokenUse: TokenUseAccess,
+		Act:      act,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	})
+	require.NoError(t, err)
+	return token
+}
+
+func exchangeForm(subjectToken string) url.Values {
+	return url.Values{
+		"grant_type":         {GrantTypeTokenExchange},
+		"client_id":          {"spa"},
+		"subject_token":      {subjectToken},
+		"subject_token_type": {TokenTypeAccessToken},
+		"audience":           {"https://orders.example.com"},
+	}
+}
+
+func TestTokenExchange(t *testing.T) {
+	t.Run("impersonation 으로 downscope 된 토큰 발급", func(t *testing.T) {
+		s := newExchangeTestServer()
+		form := exchangeForm(createTestToken(t, s, "user-1", "read write", nil))
+		form.Set("scope", "read")
+
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(form))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp TokenResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, TokenTypeAccessToken, resp.IssuedTokenType)
+		assert.Equal(t, "read", resp.Scope)
+		assert.Empty(t, resp.RefreshToken)
+
+		claims, err := s.tokens.ValidateToken(resp.AccessToken, &TokenClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+		assert.Equal(t, jwt.ClaimStrings{"https://orders.example.com"}, claims.Audience)
+		assert.Equal(t, "read", claims.Scope)
+		assert.Nil(t, claims.Act)
+	})
+
+	t.Run("delegation 시 act 클레임 체인 중첩", func(t *testing.T) {
+		s := newExchangeTestServer()
+		subjectToken := createTestToken(t, s, "user-1", "read", &ActClaim{Subject: "gateway"})
+		form := exchangeForm(subjectToken)
+		form.Set("actor_token", createTestToken(t, s, "service-a", "read", nil))
+		form.Set("actor_token_type", TokenTypeJWT)
+
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(form))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp TokenResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		claims, err := s.tokens.ValidateToken(resp.AccessToken, &TokenClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+		assert.Equal(t, &ActClaim{Subject: "service-a", Act: &ActClaim{Subject: "gateway"}}, claims.Act)
+	})
+
+	t.Run("발급 토큰은 subject_token 보다 오래 유효하지 않음", func(t *testing.T) {
+		s := newExchangeTestServer()
+		now := time.Now()
+		subjectToken, err := s.tokens.CreateToken(&TokenClaims{
+			Scope:    "read",
+			TokenUse: TokenUseAccess,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "user-1",
+				ExpiresAt: jwt.NewNumericDate(now.Add(30 * time.Second)),
+			},
+		})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(exchangeForm(subjectToken)))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp TokenResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.LessOrEqual(t, resp.ExpiresIn, int64(30))
+	})
+
+	tests := []struct {
+		name   string
+		modify func(s *Server, form url.Values)
+		code   string
+	}{
+		{
+			name: "허용되지 않은 audience",
+			modify: func(s *Server, form url.Values) {
+				form.Set("audience", "https://billing.example.com")
+			},
+			code: ErrorInvalidTarget,
+		},
+		{
+			name: "audience 누락",
+			modify: func(s *Server, form url.Values) {
+				form.Del("audience")
+			},
+			code: ErrorInvalidTarget,
+		},
+		{
+			name: "subject_token 보다 넓은 scope",
+			modify: func(s *Server, form url.Values) {
+				form.Set("scope", "read write")
+			},
+			code: ErrorInvalidScope,
+		},
+		{
+			name: "audience 에 허용되지 않은 scope",
+			modify: func(s *Server, form url.Values) {
+				form.Set("subject_token", createTestToken(t, s, "user-1", "read write", nil))
+				form.Set("scope", "write")
+			},
+			code: ErrorInvalidScope,
+		},
+		{
+			name: "잘못된 subject_token",
+			modify: func(s *Server, form url.Values) {
+				form.Set("subject_token", "invalid")
+			},
+			code: ErrorInvalidGrant,
+		},
+		{
+			name: "지원하지 않는 subject_token_type",
+			modify: func(s *Server, form url.Values) {
+				form.Set("subject_token_type", "urn:ietf:params:oauth:token-type:saml2")
+			},
+			code: ErrorInvalidRequest,
+		},
+		{
+			name: "refresh 토큰은 교환 불가",
+			modify: func(s *Server, form url.Values) {
+				token, err := s.tokens.CreateToken(&TokenClaims{
+					Scope:    "read",
+					TokenUse: TokenUseRefresh,
+					RegisteredClaims: jwt.RegisteredClaims{
+						Subject:   "user-1",
+						ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+					},
+				})
+				require.NoError(t, err)
+				form.Set("subject_token", token)
+			},
+			code: ErrorInvalidGrant,
+		},
+		{
+			name: "ID 토큰은 교환 불가",
+			modify: func(s *Server, form url.Values) {
+				token, err := s.tokens.CreateToken(&oidc.IDTokenClaims{
+					RegisteredClaims: jwt.RegisteredClaims{
+						Subject:   "user-1",
+						Audience:  jwt.ClaimStrings{"spa"},
+						ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+					},
+				})
+				require.NoError(t, err)
+				form.Set("subject_token", token)
+			},
+			code: ErrorInvalidGrant,
+		},
+		{
+			name: "token_use 가 없는 토큰은 교환 불가",
+			modify: func(s *Server, form url.Values) {
+				token, err := s.tokens.CreateToken(&TokenClaims{
+					Scope: "read",
+					RegisteredClaims: jwt.RegisteredClaims{
+						Subject:   "user-1",
+						ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+					},
+				})
+				require.NoError(t, err)
+				form.Set("subject_token", token)
+			},
+			code: ErrorInvalidGrant,
+		},
+		{
+			name: "ID 토큰을 actor_token 으로 사용 불가",
+			modify: func(s *Server, form url.Values) {
+				token, err := s.tokens.CreateToken(&oidc.IDTokenClaims{
+					RegisteredClaims: jwt.RegisteredClaims{
+						Subject:   "service-1",
+						ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+					},
+				})
+				require.NoError(t, err)
+				form.Set("actor_token", token)
+				form.Set("actor_token_type", TokenTypeJWT)
+			},
+			code: ErrorInvalidGrant,
+		},
+		{
+			name: "잘못된 actor_token",
+			modify: func(s *Server, form url.Values) {
+				form.Set("actor_token", "invalid")
+				form.Set("actor_token_type", TokenTypeAccessToken)
+			},
+			code: ErrorInvalidGrant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newExchangeTestServer()
+			form := exchangeForm(createTestToken(t, s, "user-1", "read", nil))
+			tt.modify(s, form)
+
+			w := httptest.NewRecorder()
+			s.HandleToken(w, tokenRequest(form))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.code)
+		})
+	}
+
+	t.Run("정책에서 위임을 허용하지 않음", func(t *testing.T) {
+		s := newTestServer(WithTokenExchange(&StaticExchangePolicy{
+			Audiences: map[string][]string{"https://orders.example.com": {"read"}},
+			Clients:   []string{"spa"},
+		}))
+		form := exchangeForm(createTestToken(t, s, "user-1", "read", nil))
+		form.Set("actor_token", createTestToken(t, s, "service-a", "read", nil))
+		form.Set("actor_token_type", TokenTypeAccessToken)
+
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(form))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrorInvalidRequest)
+	})
+
+	t.Run("정책의 클라이언트 목록", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			clients []string
+		}{
+			{name: "목록이 비어있으면 모든 클라이언트 거부"},
+			{name: "목록에 없는 클라이언트 거부", clients: []string{"backend"}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				s := newTestServer(WithTokenExchange(&StaticExchangePolicy{
+					Audiences: map[string][]string{"https://orders.example.com": {"read"}},
+					Clients:   tc.clients,
+				}))
+
+				w := httptest.NewRecorder()
+				s.HandleToken(w, tokenRequest(exchangeForm(createTestToken(t, s, "user-1", "read", nil))))
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Contains(t, w.Body.String(), ErrorUnauthorizedClient)
+			})
+		}
+	})
+
+	t.Run("token exchange 미설정 시 grant 거부", func(t *testing.T) {
+		s := newTestServer()
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(exchangeForm(createTestToken(t, s, "user-1", "read", nil))))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrorUnsupportedGrantType)
+		assert.NotContains(t, s.Metadata().GrantTypesSupported, GrantTypeTokenExchange)
+	})
+}
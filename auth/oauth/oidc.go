@@ -0,0 +1,150 @@
+package oauth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/oidc"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+const (
+	ScopeOpenID = "openid"
+	JWKSPath    = "/.well-known/jwks.json"
+)
+
+// UserInfoFunc: /userinfo 에서 반환할 사용자 클레임 조회 훅
+// sub 클레임은 토큰의 subject 로 항상 덮어씀
+type UserInfoFunc func(ctx context.Context, subject string, scopes []string) (map[string]interface{}, error)
+
+// WithIDTokenCreator: ID 토큰 서명에 사용할 Creator
+// 클라이언트가 JWKS 로 검증할 수 있도록 비대칭 키와 kid 를 설정한 Creator 사용을 권장
+func WithIDTokenCreator(creator *v4jwt.Creator) ServerOption {
+	return func(s *Server) {
+		s.idTokens = creator
+	}
+}
+
+// WithUserInfo: /userinfo 사용자 클레임 조회 훅
+func WithUserInfo(fn UserInfoFunc) ServerOption {
+	return func(s *Server) {
+		s.userInfo = fn
+	}
+}
+
+// createIDToken: ID 토큰 발급 (OpenID Connect Core 1.0 Section 3.1.3.6)
+func (s *Server) createIDToken(client *Client, authCode *AuthorizationCode, accessToken string, now time.Time) (string, error) {
+	atHash, err := oidc.TokenHash(accessToken, s.idTokens.Method().Alg())
+	if err != nil {
+		return "", err
+	}
+
+	return s.idTokens.CreateTypedToken(&oidc.IDTokenClaims{
+		Nonce:           authCode.Nonce,
+		AuthTime:        jwt.NewNumericDate(authCode.AuthTime),
+		AccessTokenHash: atHash,
+		AuthorizedParty: client.ID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   authCode.Subject,
+			Audience:  jwt.ClaimStrings{client.ID},
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}, v4jwt.IDTokenType)
+}
+
+// Metadata: /.well-known/openid-configuration 문서
+func (s *Server) Metadata() *oidc.ProviderMetadata {
+	issuer := strings.TrimSuffix(s.issuer, "/")
+
+	grantTypes := []string{"authorization_code", "refresh_token"}
+	if s.exchanger != nil {
+		grantTypes = append(grantTypes, GrantTypeTokenExchange)
+	}
+
+	var algs []string
+	if s.idTokens != nil {
+		algs = []string{s.idTokens.Method().Alg()}
+	}
+
+	return &oidc.ProviderMetadata{
+		Issuer:                            s.issuer,
+		AuthorizationEndpoint:             issuer + "/authorize",
+		TokenEndpoint:                     s.tokenEndpoint(),
+		UserInfoEndpoint:                  issuer + "/userinfo",
+		JWKSURI:                           issuer + JWKSPath,
+		ScopesSupported:                   []string{ScopeOpenID},
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               grantTypes,
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  algs,
+		TokenEndpointAuthMethodsSupported: []string{"none", "client_secret_basic", "client_secret_post", "private_key_jwt"},
+		CodeChallengeMethodsSupported:     []string{CodeChallengeMethodS256},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "at_hash", "azp"},
+	}
+}
+
+// JWKSHandler: ID 토큰 검증키를 제공하는 핸들러
+func (s *Server) JWKSHandler() http.Handler {
+	jwks := &v4jwt.JWKS{Keys: []v4jwt.JWK{}}
+	if s.idTokens != nil {
+		if keys, err := v4jwt.NewJWKS(s.idTokens.Config); err == nil {
+			jwks = keys
+		}
+	}
+	return v4jwt.JWKSHandler(jwks)
+}
+
+// UserInfoHandler: /userinfo 엔드포인트 (OpenID Connect Core 1.0 Section 5.3)
+// access 토큰은 JwtMiddleware 로 검증하고 컨텍스트의 클레임을 읽어 응답
+func (s *Server) UserInfoHandler() http.Handler {
+	middleware := v4jwt.NewJwtMiddleware(
+		v4jwt.AuthHeaderExtractor,
+		v4jwt.ClaimsValidator[*TokenClaims](s.tokens),
+		userInfoErrorHandler,
+		&TokenClaims{},
+	)
+	return middleware.CheckJwt(http.HandlerFunc(s.handleUserInfo))
+}
+
+func (s *Server) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	claims, ok := v4jwt.ClaimsFromContext[*TokenClaims](r.Context())
+	if !ok || claims.TokenUse != TokenUseAccess {
+		userInfoErrorHandler(w, r, v4jwt.ErrTokenInvalidClaims)
+		return
+	}
+
+	scopes := splitScope(claims.Scope)
+	if !contains(scopes, ScopeOpenID) {
+		w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="openid"`)
+		writeJSON(w, http.StatusForbidden, newError(http.StatusForbidden, "insufficient_scope", ""))
+		return
+	}
+
+	info := map[string]interface{}{}
+	if s.userInfo != nil {
+		var err error
+		info, err = s.userInfo(r.Context(), claims.Subject, scopes)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		// 추가할 클레임이 없어 nil 을 반환한 경우
+		if info == nil {
+			info = map[string]interface{}{}
+		}
+	}
+	info["sub"] = claims.Subject
+
+	writeJSON(w, http.StatusOK, info)
+}
+
+// userInfoErrorHandler: RFC 6750 Section 3 형식의 Bearer 토큰 에러 응답
+func userInfoErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	writeJSON(w, http.StatusUnauthorized, newError(http.StatusUnauthorized, "invalid_token", ""))
+}
@@ -0,0 +1,161 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/oidc"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOIDCServer(t *testing.T) (*Server, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idTokens := v4jwt.NewCreator(v4jwt.NewKeyPairConfig(jwt.SigningMethodRS256, key, &key.PublicKey, v4jwt.WithKeyID("id-1")))
+	userInfo := func(ctx context.Context, subject string, scopes []string) (map[string]interface{}, error) {
+		return map[string]interface{}{"email": subject + "@example.com", "sub": "overwritten"}, nil
+	}
+
+	s := newTestServer(WithIDTokenCreator(idTokens), WithUserInfo(userInfo))
+	s.clients.(*MemoryClientStore).AddClient(&Client{
+		ID:           "spa",
+		RedirectURIs: []string{testRedirectURI},
+		Scopes:       []string{ScopeOpenID, "read"},
+	})
+	return s, key
+}
+
+func TestIDTokenIssuance(t *testing.T) {
+	s, key := newTestOIDCServer(t)
+	authTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	s.authenticate = func(w http.ResponseWriter, r *http.Request) (*Authentication, bool) {
+		return &Authentication{Subject: "user-1", AuthTime: authTime}, true
+	}
+
+	params := defaultAuthorizeParams()
+	params.Set("scope", "openid read")
+	params.Set("nonce", "n-0S6_WzA2Mj")
+
+	w := httptest.NewRecorder()
+	s.HandleAuthorize(w, authorizeRequest(params))
+	require.Equal(t, http.StatusFound, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+
+	w = httptest.NewRecorder()
+	s.HandleToken(w, tokenRequest(url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {"spa"},
+		"code":          {location.Query().Get("code")},
+		"redirect_uri":  {testRedirectURI},
+		"code_verifier": {testVerifier},
+	}))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp TokenResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.NotEmpty(t, resp.IDToken)
+
+	validator := v4jwt.NewValidator[*oidc.IDTokenClaims](v4jwt.NewKeyPairConfig(jwt.SigningMethodRS256, nil, &key.PublicKey))
+	claims, err := validator.ValidateToken(resp.IDToken, &oidc.IDTokenClaims{})
+	require.NoError(t, err)
+
+	atHash, err := oidc.TokenHash(resp.AccessToken, "RS256")
+	require.NoError(t, err)
+
+	assert.Equal(t, "n-0S6_WzA2Mj", claims.Nonce)
+	assert.Equal(t, atHash, claims.AccessTokenHash)
+	assert.Equal(t, "spa", claims.AuthorizedParty)
+	assert.Equal(t, jwt.ClaimStrings{"spa"}, claims.Audience)
+	assert.Equal(t, "https://auth.example.com", claims.Issuer)
+	assert.Equal(t, authTime.Unix(), claims.AuthTime.Unix())
+
+	t.Run("userinfo 는 access 토큰의 subject 로 응답", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/userinfo", nil)
+		r.Header.Set("Authorization", "Bearer "+resp.AccessToken)
+		w := httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var info map[string]interface{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+		assert.Equal(t, "user-1", info["sub"])
+		assert.Equal(t, "user-1@example.com", info["email"])
+	})
+
+	t.Run("userinfo 훅이 nil 을 반환하면 sub 만 응답", func(t *testing.T) {
+		userInfo := s.userInfo
+		defer func() { s.userInfo = userInfo }()
+		s.userInfo = func(ctx context.Context, subject string, scopes []string) (map[string]interface{}, error) {
+			return nil, nil
+		}
+
+		r := httptest.NewRequest(http.MethodGet, "/userinfo", nil)
+		r.Header.Set("Authorization", "Bearer "+resp.AccessToken)
+		w := httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var info map[string]interface{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+		assert.Equal(t, map[string]interface{}{"sub": "user-1"}, info)
+	})
+
+	t.Run("userinfo 는 refresh 토큰을 거부", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/userinfo", nil)
+		r.Header.Set("Authorization", "Bearer "+resp.RefreshToken)
+		w := httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, r)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), "invalid_token")
+	})
+
+	t.Run("userinfo 는 토큰이 없으면 거부", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/userinfo", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestDiscovery(t *testing.T) {
+	s, key := newTestOIDCServer(t)
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(oidc.DiscoveryURL(server.URL))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var metadata oidc.ProviderMetadata
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&metadata))
+	assert.Equal(t, "https://auth.example.com", metadata.Issuer)
+	assert.Equal(t, "https://auth.example.com/token", metadata.TokenEndpoint)
+	assert.Equal(t, "https://auth.example.com"+JWKSPath, metadata.JWKSURI)
+	assert.Equal(t, []string{"RS256"}, metadata.IDTokenSigningAlgValuesSupported)
+	assert.Equal(t, []string{"S256"}, metadata.CodeChallengeMethodsSupported)
+
+	resp, err = http.Get(server.URL + JWKSPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var jwks v4jwt.JWKS
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks))
+	jwk, err := jwks.Key(context.Background(), "id-1")
+	require.NoError(t, err)
+	publicKey, err := jwk.PublicKey()
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(publicKey))
+}
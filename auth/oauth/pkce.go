@@ -0,0 +1,52 @@
+package oauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+// PKCE (RFC 7636) 는 S256 만 지원
+// plain 방식은 code_challenge 가 그대로 노출되므로 허용하지 않음
+const CodeChallengeMethodS256 = "S256"
+
+// S256Challenge: code_verifier 로부터 code_challenge 를 계산
+// BASE64URL(SHA256(ASCII(code_verifier)))
+func S256Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// VerifyCodeChallenge: code_verifier 가 저장된 code_challenge 와 일치하는지 확인
+func VerifyCodeChallenge(challenge, verifier string) bool {
+	if !validVerifier(verifier) {
+		return false
+	}
+	computed := S256Challenge(verifier)
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// code_verifier 는 43~128 자의 unreserved 문자 [A-Z] / [a-z] / [0-9] / "-" / "." / "_" / "~"
+func validVerifier(verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	for _, c := range verifier {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case c == '-', c == '.', c == '_', c == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// S256 challenge 는 32 byte 해시의 base64url 인코딩이므로 항상 43 자
+func validChallenge(challenge string) bool {
+	if len(challenge) != 43 {
+		return false
+	}
+	_, err := base64.RawURLEncoding.DecodeString(challenge)
+	return err == nil
+}
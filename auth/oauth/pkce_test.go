@@ -0,0 +1,56 @@
+package oauth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	// RFC 7636 Appendix B 예제 값
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	testCases := []struct {
+		name      string
+		challenge string
+		verifier  string
+		expected  bool
+	}{
+		{
+			name:      "RFC 7636 예제 값이 일치하는 경우",
+			challenge: challenge,
+			verifier:  verifier,
+			expected:  true,
+		},
+		{
+			name:      "verifier 가 다른 경우",
+			challenge: challenge,
+			verifier:  strings.Repeat("a", 43),
+			expected:  false,
+		},
+		{
+			name:      "verifier 가 43자 미만인 경우",
+			challenge: S256Challenge("short"),
+			verifier:  "short",
+			expected:  false,
+		},
+		{
+			name:      "verifier 에 허용되지 않는 문자가 있는 경우",
+			challenge: S256Challenge(strings.Repeat("a", 42) + "+"),
+			verifier:  strings.Repeat("a", 42) + "+",
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, VerifyCodeChallenge(tc.challenge, tc.verifier))
+		})
+	}
+
+	assert.Equal(t, challenge, S256Challenge(verifier))
+}
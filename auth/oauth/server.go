@@ -0,0 +1,205 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/oidc"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+const (
+	defaultCodeTTL         = time.Minute
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+const (
+	TokenUseAccess  = "access"
+	TokenUseRefresh = "refresh"
+)
+
+// TokenClaims: 인가 서버가 발급하는 access / refresh 토큰의 클레임
+type TokenClaims struct {
+	ClientID string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+	TokenUse string `json:"token_use,omitempty"`
+	// Act: 토큰 교환으로 위임된 경우의 actor 체인
+	Act *ActClaim `json:"act,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (c *TokenClaims) Valid() error {
+	return c.RegisteredClaims.Valid()
+}
+
+// Authentication: 사용자 인증 결과
+type Authentication struct {
+	Subject  string
+	AuthTime time.Time
+}
+
+// AuthenticateFunc: /authorize 요청의 사용자를 인증하는 훅
+// 인증되지 않은 경우 로그인 페이지로 리다이렉트 하는 등 직접 응답을 작성하고 false 반환
+type AuthenticateFunc func(w http.ResponseWriter, r *http.Request) (*Authentication, bool)
+
+// ConsentFunc: 사용자 동의를 받는 훅
+// 동의한 scope 목록을 반환하며, 응답을 직접 작성한 경우 (동의 화면 등) false 반환
+// 요청되지 않은 scope 는 무시
+type ConsentFunc func(w http.ResponseWriter, r *http.Request, client *Client, auth *Authentication, scopes []string) ([]string, bool)
+
+// Server: authorization_code + PKCE 를 지원하는 최소한의 인가 서버
+type Server struct {
+	clients ClientStore
+	codes   CodeStore
+	tokens  v4jwt.Manager[*TokenClaims]
+
+	authenticate AuthenticateFunc
+	consent      ConsentFunc
+	idTokens     *v4jwt.Creator
+	userInfo     UserInfoFunc
+	assertions   *ClientAssertionVerifier
+	// assertionOptions: assertions 생성에 사용하는 옵션
+	assertionOptions []ClientAssertionOption
+
+	exchangePolicy ExchangePolicy
+	exchanger      *TokenExchanger
+
+	issuer          string
+	audience        []string
+	codeTTL         time.Duration
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	now             func() time.Time
+}
+
+type ServerOption func(*Server)
+
+// WithAuthenticator: 사용자 인증 훅 설정, 설정하지 않으면 모든 요청을 거부
+func WithAuthenticator(fn AuthenticateFunc) ServerOption {
+	return func(s *Server) {
+		s.authenticate = fn
+	}
+}
+
+// WithConsent: 사용자 동의 훅 설정, 설정하지 않으면 first-party 앱으로 보고 요청된 scope 를 모두 허용
+func WithConsent(fn ConsentFunc) ServerOption {
+	return func(s *Server) {
+		s.consent = fn
+	}
+}
+
+// WithIssuer: 발급하는 토큰의 iss 클레임
+func WithIssuer(issuer string) ServerOption {
+	return func(s *Server) {
+		s.issuer = issuer
+	}
+}
+
+// WithAudience: 발급하는 access 토큰의 aud 클레임
+func WithAudience(audience ...string) ServerOption {
+	return func(s *Server) {
+		s.audience = audience
+	}
+}
+
+// WithCodeTTL: 인가 코드 유효 시간, RFC 6749 권고에 따라 최대 10분으로 제한
+func WithCodeTTL(ttl time.Duration) ServerOption {
+	return func(s *Server) {
+		if ttl > 10*time.Minute {
+			ttl = 10 * time.Minute
+		}
+		s.codeTTL = ttl
+	}
+}
+
+func WithAccessTokenTTL(ttl time.Duration) ServerOption {
+	return func(s *Server) {
+		s.accessTokenTTL = ttl
+	}
+}
+
+func WithRefreshTokenTTL(ttl time.Duration) ServerOption {
+	return func(s *Server) {
+		s.refreshTokenTTL = ttl
+	}
+}
+
+// NewServer: tokens 의 Creator 는 access, refresh 토큰의 typ 을 구분하기 위해 v4jwt.TypedTokenCreator 여야 함
+func NewServer(clients ClientStore, codes CodeStore, tokens v4jwt.Manager[*TokenClaims], opts ...ServerOption) *Server {
+	s := &Server{
+		clients:         clients,
+		codes:           codes,
+		tokens:          tokens,
+		authenticate:    denyAuthenticate,
+		consent:         grantAllConsent,
+		codeTTL:         defaultCodeTTL,
+		accessTokenTTL:  defaultAccessTokenTTL,
+		refreshTokenTTL: defaultRefreshTokenTTL,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.assertions = NewClientAssertionVerifier(clients, s.tokenEndpoint(), s.assertionOptions...)
+	if s.exchangePolicy != nil {
+		s.exchanger = NewTokenExchanger(tokens, tokens, s.exchangePolicy, WithExchangeIssuer(s.issuer), WithExchangeTTL(s.accessTokenTTL))
+	}
+	return s
+}
+
+// tokenEndpoint: client assertion 의 aud 로 사용하는 토큰 엔드포인트 URL
+func (s *Server) tokenEndpoint() string {
+	return strings.TrimSuffix(s.issuer, "/") + "/token"
+}
+
+// Handler: /authorize, /token 및 OpenID Connect 엔드포인트를 등록한 http.Handler
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", s.HandleAuthorize)
+	mux.HandleFunc("/token", s.HandleToken)
+	mux.Handle("/userinfo", s.UserInfoHandler())
+	mux.Handle(oidc.DiscoveryPath, oidc.DiscoveryHandler(s.Metadata()))
+	mux.Handle(JWKSPath, s.JWKSHandler())
+	return mux
+}
+
+func denyAuthenticate(w http.ResponseWriter, _ *http.Request) (*Authentication, bool) {
+	writeError(w, newError(http.StatusUnauthorized, ErrorAccessDenied, "user authentication is not configured"))
+	return nil, false
+}
+
+func grantAllConsent(_ http.ResponseWriter, _ *http.Request, _ *Client, _ *Authentication, scopes []string) ([]string, bool) {
+	return scopes, true
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func splitScope(scope string) []string {
+	return strings.Fields(scope)
+}
+
+func joinScope(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+// intersectScopes: granted 중 requested 에 포함된 scope, 중복은 제거
+func intersectScopes(granted, requested []string) []string {
+	var scopes []string
+	for _, scope := range granted {
+		if contains(requested, scope) && !contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,343 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testVerifier    = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testRedirectURI = "https://app.example.com/callback"
+)
+
+func newTestServer(opts ...ServerOption) *Server {
+	config := v4jwt.NewConfig(jwt.SigningMethodHS256, []byte("secret"))
+	manager := v4jwt.NewTokenManager[*TokenClaims](v4jwt.NewCreator(config), v4jwt.NewValidator[*TokenClaims](config))
+
+	clients := NewMemoryClientStore(&Client{
+		ID:           "spa",
+		RedirectURIs: []string{testRedirectURI},
+		Scopes:       []string{"read", "write"},
+	})
+
+	authenticate := func(w http.ResponseWriter, r *http.Request) (*Authentication, bool) {
+		return &Authentication{Subject: "user-1"}, true
+	}
+
+	opts = append([]ServerOption{WithAuthenticator(authenticate), WithIssuer("https://auth.example.com")}, opts...)
+	return NewServer(clients, NewMemoryCodeStore(), manager, opts...)
+}
+
+func authorizeRequest(params url.Values) *http.Request {
+	return httptest.NewRequest(http.MethodGet, "/authorize?"+params.Encode(), nil)
+}
+
+func defaultAuthorizeParams() url.Values {
+	return url.Values{
+		"response_type":         {"code"},
+		"client_id":             {"spa"},
+		"redirect_uri":          {testRedirectURI},
+		"scope":                 {"read"},
+		"state":                 {"xyz"},
+		"code_challenge":        {S256Challenge(testVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+}
+
+func tokenRequest(form url.Values) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func authorize(t *testing.T, s *Server) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	s.HandleAuthorize(w, authorizeRequest(defaultAuthorizeParams()))
+	require.Equal(t, http.StatusFound, w.Code)
+
+	location, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "xyz", location.Query().Get("state"))
+	return location.Query().Get("code")
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	t.Run("코드 발급 및 토큰 교환 성공", func(t *testing.T) {
+		s := newTestServer()
+		code := authorize(t, s)
+		require.NotEmpty(t, code)
+
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {"spa"},
+			"code":          {code},
+			"redirect_uri":  {testRedirectURI},
+			"code_verifier": {testVerifier},
+		}))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+		var resp TokenResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "Bearer", resp.TokenType)
+		assert.Equal(t, "read", resp.Scope)
+
+		claims, err := s.tokens.ValidateToken(resp.AccessToken, &TokenClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+		assert.Equal(t, "spa", claims.ClientID)
+		assert.Equal(t, TokenUseAccess, claims.TokenUse)
+
+		// 같은 코드 재사용 불가
+		w = httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {"spa"},
+			"code":          {code},
+			"redirect_uri":  {testRedirectURI},
+			"code_verifier": {testVerifier},
+		}))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrorInvalidGrant)
+
+		// refresh 토큰으로 재발급
+		w = httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {"spa"},
+			"refresh_token": {resp.RefreshToken},
+		}))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// access 토큰은 refresh 토큰으로 사용 불가
+		w = httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {"spa"},
+			"refresh_token": {resp.AccessToken},
+		}))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("발급한 토큰의 typ 으로 리소스 서버가 구분", func(t *testing.T) {
+		s := newTestServer()
+		code := authorize(t, s)
+
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {"spa"},
+			"code":          {code},
+			"redirect_uri":  {testRedirectURI},
+			"code_verifier": {testVerifier},
+		}))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp TokenResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+		// 인가 서버와 같은 키를 사용하는 리소스 서버
+		config := v4jwt.NewConfig(jwt.SigningMethodHS256, []byte("secret"))
+		resource := v4jwt.NewValidator[*TokenClaims](config, v4jwt.WithExpectedTypes(v4jwt.AccessTokenType))
+
+		_, err := resource.ValidateToken(resp.AccessToken, &TokenClaims{})
+		assert.NoError(t, err)
+		_, err = resource.ValidateToken(resp.RefreshToken, &TokenClaims{})
+		assert.ErrorIs(t, err, v4jwt.ErrTokenTypeMismatch)
+
+		// token_use 가 refresh 여도 typ 이 없는 토큰은 refresh 토큰으로 사용 불가
+		untyped, err := v4jwt.NewCreator(config).CreateToken(&TokenClaims{
+			ClientID: "spa",
+			Scope:    "read",
+			TokenUse: TokenUseRefresh,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "user-1",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		})
+		require.NoError(t, err)
+		w = httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {"spa"},
+			"refresh_token": {untyped},
+		}))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("code_verifier 가 일치하지 않는 경우", func(t *testing.T) {
+		s := newTestServer()
+		code := authorize(t, s)
+
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {"spa"},
+			"code":          {code},
+			"redirect_uri":  {testRedirectURI},
+			"code_verifier": {strings.Repeat("x", 43)},
+		}))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrorInvalidGrant)
+	})
+
+	t.Run("redirect_uri 가 일치하지 않는 경우", func(t *testing.T) {
+		s := newTestServer()
+		code := authorize(t, s)
+
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {"spa"},
+			"code":          {code},
+			"redirect_uri":  {testRedirectURI + "/other"},
+			"code_verifier": {testVerifier},
+		}))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("만료된 코드", func(t *testing.T) {
+		s := newTestServer()
+		code := authorize(t, s)
+
+		store := s.codes.(*MemoryCodeStore)
+		store.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+		w := httptest.NewRecorder()
+		s.HandleToken(w, tokenRequest(url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {"spa"},
+			"code":          {code},
+			"redirect_uri":  {testRedirectURI},
+			"code_verifier": {testVerifier},
+		}))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrorInvalidGrant)
+	})
+}
+
+func TestHandleAuthorize(t *testing.T) {
+	testCases := []struct {
+		name           string
+		modify         func(url.Values)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "등록되지 않은 redirect_uri 는 리다이렉트 하지 않음",
+			modify:         func(v url.Values) { v.Set("redirect_uri", "https://evil.example.com/callback") },
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ErrorInvalidRequest,
+		},
+		{
+			name:           "redirect_uri 는 prefix 매칭을 허용하지 않음",
+			modify:         func(v url.Values) { v.Set("redirect_uri", testRedirectURI+"/extra") },
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ErrorInvalidRequest,
+		},
+		{
+			name:           "등록되지 않은 클라이언트",
+			modify:         func(v url.Values) { v.Set("client_id", "unknown") },
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ErrorInvalidClient,
+		},
+		{
+			name:           "plain code_challenge_method 는 허용하지 않음",
+			modify:         func(v url.Values) { v.Set("code_challenge_method", "plain") },
+			expectedStatus: http.StatusFound,
+			expectedError:  ErrorInvalidRequest,
+		},
+		{
+			name:           "code_challenge 가 없는 경우",
+			modify:         func(v url.Values) { v.Del("code_challenge") },
+			expectedStatus: http.StatusFound,
+			expectedError:  ErrorInvalidRequest,
+		},
+		{
+			name:           "허용되지 않은 scope",
+			modify:         func(v url.Values) { v.Set("scope", "admin") },
+			expectedStatus: http.StatusFound,
+			expectedError:  ErrorInvalidScope,
+		},
+		{
+			name:           "지원하지 않는 response_type",
+			modify:         func(v url.Values) { v.Set("response_type", "token") },
+			expectedStatus: http.StatusFound,
+			expectedError:  ErrorUnsupportedResponseType,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := newTestServer()
+			params := defaultAuthorizeParams()
+			tc.modify(params)
+
+			w := httptest.NewRecorder()
+			s.HandleAuthorize(w, authorizeRequest(params))
+			require.Equal(t, tc.expectedStatus, w.Code)
+
+			if tc.expectedStatus == http.StatusFound {
+				location, err := url.Parse(w.Header().Get("Location"))
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedError, location.Query().Get("error"))
+				assert.Equal(t, "xyz", location.Query().Get("state"))
+				assert.Empty(t, location.Query().Get("code"))
+				return
+			}
+			assert.Contains(t, w.Body.String(), tc.expectedError)
+		})
+	}
+}
+
+func TestConsent(t *testing.T) {
+	t.Run("동의 훅이 scope 를 축소하는 경우", func(t *testing.T) {
+		consent := func(w http.ResponseWriter, r *http.Request, c *Client, a *Authentication, scopes []string) ([]string, bool) {
+			return nil, true
+		}
+		s := newTestServer(WithConsent(consent))
+		code := authorize(t, s)
+
+		authCode, err := s.codes.Consume(httptest.NewRequest(http.MethodGet, "/", nil).Context(), code)
+		require.NoError(t, err)
+		assert.Empty(t, authCode.Scopes)
+	})
+
+	t.Run("동의 훅이 요청되지 않은 scope 를 반환하는 경우", func(t *testing.T) {
+		consent := func(w http.ResponseWriter, r *http.Request, c *Client, a *Authentication, scopes []string) ([]string, bool) {
+			return []string{"read", "write", "admin", "read"}, true
+		}
+		s := newTestServer(WithConsent(consent))
+		code := authorize(t, s)
+
+		authCode, err := s.codes.Consume(httptest.NewRequest(http.MethodGet, "/", nil).Context(), code)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"read"}, authCode.Scopes)
+	})
+
+	t.Run("동의 훅이 거부하는 경우 코드를 발급하지 않음", func(t *testing.T) {
+		consent := func(w http.ResponseWriter, r *http.Request, c *Client, a *Authentication, scopes []string) ([]string, bool) {
+			w.WriteHeader(http.StatusForbidden)
+			return nil, false
+		}
+		s := newTestServer(WithConsent(consent))
+
+		w := httptest.NewRecorder()
+		s.HandleAuthorize(w, authorizeRequest(defaultAuthorizeParams()))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get("Location"))
+	})
+}
@@ -0,0 +1,95 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrCodeNotFound = errors.New("authorization code not found")
+	ErrCodeExpired  = errors.New("authorization code expired")
+)
+
+// AuthorizationCode: /authorize 에서 발급되어 /token 에서 교환되는 인가 코드
+type AuthorizationCode struct {
+	Code          string
+	ClientID      string
+	RedirectURI   string
+	Subject       string
+	Scopes        []string
+	CodeChallenge string
+	Nonce         string
+	AuthTime      time.Time
+	ExpiresAt     time.Time
+}
+
+// CodeStore: 인가 코드 저장소 인터페이스
+// Consume 은 코드를 조회하는 동시에 삭제해야 하며 (single-use)
+// 만료된 코드는 ErrCodeExpired 를 반환해야 함
+type CodeStore interface {
+	Save(ctx context.Context, code *AuthorizationCode) error
+	Consume(ctx context.Context, code string) (*AuthorizationCode, error)
+}
+
+// minCodeSweepSize: 만료된 코드를 정리하기 시작하는 코드 수
+const minCodeSweepSize = 1024
+
+// MemoryCodeStore: 메모리 기반 CodeStore 구현체
+// 단일 인스턴스 환경에서만 사용, 다중 인스턴스 환경에서는 Redis 등 공유 저장소로 구현
+// 만료된 코드는 코드 수가 마지막 정리 후 남은 수의 두 배가 될 때 정리하여 Save 의 비용은 분할 상환 O(1)
+type MemoryCodeStore struct {
+	mu    sync.Mutex
+	codes map[string]*AuthorizationCode
+	now   func() time.Time
+	// sweepSize: 만료된 코드를 정리할 코드 수
+	sweepSize int
+}
+
+func NewMemoryCodeStore() *MemoryCodeStore {
+	return &MemoryCodeStore{
+		codes:     make(map[string]*AuthorizationCode),
+		now:       time.Now,
+		sweepSize: minCodeSweepSize,
+	}
+}
+
+func (s *MemoryCodeStore) Save(_ context.Context, code *AuthorizationCode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.codes[code.Code] = code
+	if len(s.codes) >= s.sweepSize {
+		s.sweep()
+	}
+	return nil
+}
+
+func (s *MemoryCodeStore) Consume(_ context.Context, code string) (*AuthorizationCode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.codes[code]
+	if !ok {
+		return nil, ErrCodeNotFound
+	}
+	// 조회와 동시에 삭제하여 재사용 방지
+	delete(s.codes, code)
+
+	if !s.now().Before(c.ExpiresAt) {
+		return nil, ErrCodeExpired
+	}
+	return c, nil
+}
+
+// sweep: 만료된 코드를 삭제하고 다음 정리 시점 설정, mu 를 잡은 상태에서 호출
+func (s *MemoryCodeStore) sweep() {
+	now := s.now()
+	for k, c := range s.codes {
+		if !now.Before(c.ExpiresAt) {
+			delete(s.codes, k)
+		}
+	}
+	s.sweepSize = max(2*len(s.codes), minCodeSweepSize)
+}
@@ -0,0 +1,70 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCodeStore(t *testing.T) {
+	t.Run("코드는 한번만 사용 가능", func(t *testing.T) {
+		store := NewMemoryCodeStore()
+		err := store.Save(context.Background(), &AuthorizationCode{
+			Code:      "code",
+			ExpiresAt: time.Now().Add(time.Minute),
+		})
+		require.NoError(t, err)
+
+		code, err := store.Consume(context.Background(), "code")
+		require.NoError(t, err)
+		assert.Equal(t, "code", code.Code)
+
+		_, err = store.Consume(context.Background(), "code")
+		assert.ErrorIs(t, err, ErrCodeNotFound)
+	})
+
+	t.Run("만료된 코드는 사용 불가", func(t *testing.T) {
+		store := NewMemoryCodeStore()
+		now := time.Now()
+		store.now = func() time.Time { return now }
+
+		err := store.Save(context.Background(), &AuthorizationCode{
+			Code:      "code",
+			ExpiresAt: now.Add(time.Minute),
+		})
+		require.NoError(t, err)
+
+		store.now = func() time.Time { return now.Add(2 * time.Minute) }
+		_, err = store.Consume(context.Background(), "code")
+		assert.ErrorIs(t, err, ErrCodeExpired)
+	})
+
+	t.Run("만료된 코드는 정리 시점에 삭제", func(t *testing.T) {
+		ctx := context.Background()
+		store := NewMemoryCodeStore()
+		now := time.Now()
+		store.now = func() time.Time { return now }
+
+		// 정리 시점 전까지는 만료된 코드도 남아있음
+		for i := 0; i < minCodeSweepSize-1; i++ {
+			require.NoError(t, store.Save(ctx, &AuthorizationCode{Code: fmt.Sprintf("expired-%d", i), ExpiresAt: now.Add(time.Second)}))
+		}
+		store.now = func() time.Time { return now.Add(time.Minute) }
+		assert.Len(t, store.codes, minCodeSweepSize-1)
+
+		require.NoError(t, store.Save(ctx, &AuthorizationCode{Code: "code", ExpiresAt: now.Add(time.Hour)}))
+		assert.Len(t, store.codes, 1)
+		assert.Equal(t, minCodeSweepSize, store.sweepSize)
+
+		// 만료되지 않은 코드가 많으면 정리 간격도 늘어남
+		for i := 0; i < minCodeSweepSize; i++ {
+			require.NoError(t, store.Save(ctx, &AuthorizationCode{Code: fmt.Sprintf("live-%d", i), ExpiresAt: now.Add(time.Hour)}))
+		}
+		assert.Len(t, store.codes, minCodeSweepSize+1)
+		assert.Equal(t, 2*minCodeSweepSize, store.sweepSize)
+	})
+}
@@ -0,0 +1,245 @@
+package oauth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+// tokenTypes: TokenUse 별 헤더의 typ
+// 리소스 서버가 v4jwt.WithExpectedTypes 로 refresh 토큰을 access 토큰으로 사용하는 것을 막을 수 있도록 함
+var tokenTypes = map[string]string{
+	TokenUseAccess:  v4jwt.AccessTokenType,
+	TokenUseRefresh: v4jwt.RefreshTokenType,
+}
+
+// TokenResponse: 토큰 엔드포인트 성공 응답 (RFC 6749 Section 5.1)
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	// IssuedTokenType: 토큰 교환 응답에만 포함 (RFC 8693 Section 2.2.1)
+	IssuedTokenType string `json:"issued_token_type,omitempty"`
+}
+
+// HandleToken: 토큰 엔드포인트 (RFC 6749 Section 4.1.3, 6)
+// authorization_code, refresh_token, token-exchange grant 를 지원
+func (s *Server) HandleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		writeError(w, newError(http.StatusMethodNotAllowed, ErrorInvalidRequest, "method not allowed"))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, newError(http.StatusBadRequest, ErrorInvalidRequest, "malformed request"))
+		return
+	}
+
+	client, err := s.authenticateClient(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var resp *TokenResponse
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		resp, err = s.exchangeAuthorizationCode(r, client)
+	case "refresh_token":
+		resp, err = s.exchangeRefreshToken(r, client)
+	case GrantTypeTokenExchange:
+		resp, err = s.exchangeToken(r, client)
+	default:
+		err = newError(http.StatusBadRequest, ErrorUnsupportedGrantType, "")
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// authenticateClient: 클라이언트 인증
+// private_key_jwt, client_secret_basic, client_secret_post 를 지원하고
+// public client 는 client_id 만 확인 (PKCE 로 보호)
+func (s *Server) authenticateClient(r *http.Request) (*Client, error) {
+	if r.PostForm.Get("client_assertion_type") == ClientAssertionType {
+		client, err := s.assertions.Verify(r.Context(), r.PostForm.Get("client_assertion"))
+		if err != nil {
+			return nil, newError(http.StatusUnauthorized, ErrorInvalidClient, "client authentication failed")
+		}
+		if clientID := r.PostForm.Get("client_id"); clientID != "" && clientID != client.ID {
+			return nil, newError(http.StatusUnauthorized, ErrorInvalidClient, "client authentication failed")
+		}
+		return client, nil
+	}
+
+	clientID, secret, basic := r.BasicAuth()
+	if !basic {
+		clientID = r.PostForm.Get("client_id")
+		secret = r.PostForm.Get("client_secret")
+	}
+
+	if clientID == "" {
+		return nil, newError(http.StatusUnauthorized, ErrorInvalidClient, "client authentication failed")
+	}
+
+	client, err := s.clients.GetClient(r.Context(), clientID)
+	if err != nil {
+		return nil, newError(http.StatusUnauthorized, ErrorInvalidClient, "client authentication failed")
+	}
+
+	switch {
+	case client.Public():
+		return client, nil
+	case client.Secret != "" && subtle.ConstantTimeCompare([]byte(client.Secret), []byte(secret)) == 1:
+		return client, nil
+	default:
+		return nil, newError(http.StatusUnauthorized, ErrorInvalidClient, "client authentication failed")
+	}
+}
+
+func (s *Server) exchangeAuthorizationCode(r *http.Request, client *Client) (*TokenResponse, error) {
+	code := r.PostForm.Get("code")
+	verifier := r.PostForm.Get("code_verifier")
+	if code == "" || verifier == "" {
+		return nil, newError(http.StatusBadRequest, ErrorInvalidRequest, "code and code_verifier are required")
+	}
+
+	// 코드는 검증 실패 여부와 관계없이 한번 조회되면 폐기됨
+	authCode, err := s.codes.Consume(r.Context(), code)
+	if err != nil {
+		if errors.Is(err, ErrCodeNotFound) || errors.Is(err, ErrCodeExpired) {
+			return nil, newError(http.StatusBadRequest, ErrorInvalidGrant, "authorization code is invalid or expired")
+		}
+		return nil, newError(http.StatusInternalServerError, ErrorServerError, "")
+	}
+
+	if authCode.ClientID != client.ID {
+		return nil, newError(http.StatusBadRequest, ErrorInvalidGrant, "authorization code was issued to another client")
+	}
+
+	if authCode.RedirectURI != r.PostForm.Get("redirect_uri") {
+		return nil, newError(http.StatusBadRequest, ErrorInvalidGrant, "redirect_uri does not match")
+	}
+
+	if !VerifyCodeChallenge(authCode.CodeChallenge, verifier) {
+		return nil, newError(http.StatusBadRequest, ErrorInvalidGrant, "code_verifier does not match code_challenge")
+	}
+
+	return s.issueTokens(client, authCode.Subject, authCode.Scopes, authCode)
+}
+
+func (s *Server) exchangeRefreshToken(r *http.Request, client *Client) (*TokenResponse, error) {
+	refreshToken := r.PostForm.Get("refresh_token")
+	if refreshToken == "" {
+		return nil, newError(http.StatusBadRequest, ErrorInvalidRequest, "refresh_token is required")
+	}
+
+	claims, err := s.tokens.ValidateToken(refreshToken, &TokenClaims{})
+	if err != nil || claims.TokenUse != TokenUseRefresh || claims.ClientID != client.ID ||
+		!strings.EqualFold(headerType(refreshToken), v4jwt.RefreshTokenType) {
+		return nil, newError(http.StatusBadRequest, ErrorInvalidGrant, "refresh token is invalid")
+	}
+
+	// scope 는 기존에 허용된 범위 내에서 축소만 가능
+	scopes := splitScope(claims.Scope)
+	if requested := r.PostForm.Get("scope"); requested != "" {
+		narrowed := splitScope(requested)
+		for _, scope := range narrowed {
+			if !contains(scopes, scope) {
+				return nil, newError(http.StatusBadRequest, ErrorInvalidScope, "requested scope exceeds the original grant")
+			}
+		}
+		scopes = narrowed
+	}
+
+	return s.issueTokens(client, claims.Subject, scopes, nil)
+}
+
+// issueTokens: TokenManager 를 통해 access / refresh 토큰 발급
+// 인가 코드 교환 시 openid scope 가 있으면 ID 토큰도 함께 발급
+func (s *Server) issueTokens(client *Client, subject string, scopes []string, authCode *AuthorizationCode) (*TokenResponse, error) {
+	now := s.now()
+	scope := joinScope(scopes)
+
+	accessToken, err := s.createToken(client, subject, scope, TokenUseAccess, s.audience, now, s.accessTokenTTL)
+	if err != nil {
+		return nil, newError(http.StatusInternalServerError, ErrorServerError, "")
+	}
+
+	refreshToken, err := s.createToken(client, subject, scope, TokenUseRefresh, nil, now, s.refreshTokenTTL)
+	if err != nil {
+		return nil, newError(http.StatusInternalServerError, ErrorServerError, "")
+	}
+
+	resp := &TokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.accessTokenTTL / time.Second),
+		RefreshToken: refreshToken,
+		Scope:        scope,
+	}
+
+	if authCode != nil && s.idTokens != nil && contains(scopes, ScopeOpenID) {
+		resp.IDToken, err = s.createIDToken(client, authCode, accessToken, now)
+		if err != nil {
+			return nil, newError(http.StatusInternalServerError, ErrorServerError, "")
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *Server) createToken(client *Client, subject, scope, use string, audience []string, now time.Time, ttl time.Duration) (string, error) {
+	jti, err := randomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	return createTypedToken(s.tokens, &TokenClaims{
+		ClientID: client.ID,
+		Scope:    scope,
+		TokenUse: use,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   subject,
+			Audience:  audience,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ID:        jti,
+		},
+	}, tokenTypes[use])
+}
+
+// createTypedToken: 헤더의 typ 을 지정하여 발급
+// creator 가 v4jwt.TypedTokenCreator 가 아니면 v4jwt.ErrTokenTypeUnsupported
+func createTypedToken(creator v4jwt.TokenCreator, claims jwt.Claims, typ string) (string, error) {
+	typed, ok := creator.(v4jwt.TypedTokenCreator)
+	if !ok {
+		return "", v4jwt.ErrTokenTypeUnsupported
+	}
+	return typed.CreateTypedToken(claims, typ)
+}
+
+// headerType: 검증을 마친 토큰 헤더의 typ
+func headerType(tokenString string) string {
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return ""
+	}
+	typ, _ := token.Header["typ"].(string)
+	return typ
+}
@@ -0,0 +1,50 @@
+package oidc
+
+import (
+	"crypto"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// IDTokenClaims: OpenID Connect ID 토큰 클레임 (OpenID Connect Core 1.0 Section 2)
+type IDTokenClaims struct {
+	Nonce           string           `json:"nonce,omitempty"`
+	AuthTime        *jwt.NumericDate `json:"auth_time,omitempty"`
+	AccessTokenHash string           `json:"at_hash,omitempty"`
+	CodeHash        string           `json:"c_hash,omitempty"`
+	AuthorizedParty string           `json:"azp,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (c *IDTokenClaims) Valid() error {
+	return c.RegisteredClaims.Valid()
+}
+
+// TokenHash: at_hash, c_hash 계산
+// ID 토큰 alg 의 해시 함수로 값을 해싱한 뒤 왼쪽 절반을 base64url 인코딩
+func TokenHash(value, alg string) (string, error) {
+	h, err := hashForAlg(alg)
+	if err != nil {
+		return "", err
+	}
+
+	hasher := h.New()
+	hasher.Write([]byte(value))
+	sum := hasher.Sum(nil)
+	return base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2]), nil
+}
+
+func hashForAlg(alg string) (crypto.Hash, error) {
+	switch alg {
+	case "HS256", "RS256", "PS256", "ES256":
+		return crypto.SHA256, nil
+	case "HS384", "RS384", "PS384", "ES384":
+		return crypto.SHA384, nil
+	case "HS512", "RS512", "PS512", "ES512", "EdDSA":
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported alg for token hash: %s", alg)
+	}
+}
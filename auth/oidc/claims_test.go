@@ -0,0 +1,54 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenHash(t *testing.T) {
+	token := "jHkWEdUXMU1BwAsC4vtUsZwnNxy2vBaAnWlbdb7zODzL"
+	sum256 := sha256.Sum256([]byte(token))
+	sum512 := sha512.Sum512([]byte(token))
+
+	testCases := []struct {
+		name     string
+		alg      string
+		expected string
+	}{
+		{
+			name:     "RS256 은 SHA-256 의 왼쪽 절반",
+			alg:      "RS256",
+			expected: base64.RawURLEncoding.EncodeToString(sum256[:16]),
+		},
+		{
+			name:     "ES256 은 SHA-256 의 왼쪽 절반",
+			alg:      "ES256",
+			expected: base64.RawURLEncoding.EncodeToString(sum256[:16]),
+		},
+		{
+			name:     "EdDSA 는 SHA-512 의 왼쪽 절반",
+			alg:      "EdDSA",
+			expected: base64.RawURLEncoding.EncodeToString(sum512[:32]),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			hash, err := TokenHash(token, tc.alg)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, hash)
+		})
+	}
+
+	t.Run("지원하지 않는 alg", func(t *testing.T) {
+		_, err := TokenHash(token, "none")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,42 @@
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// DiscoveryPath: OpenID Provider 설정 문서 경로 (OpenID Connect Discovery 1.0 Section 4)
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// ProviderMetadata: OpenID Provider 설정 문서
+type ProviderMetadata struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint,omitempty"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported,omitempty"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported,omitempty"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported,omitempty"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported,omitempty"`
+	ClaimsSupported                   []string `json:"claims_supported,omitempty"`
+}
+
+// DiscoveryURL: issuer 의 설정 문서 URL
+func DiscoveryURL(issuer string) string {
+	return strings.TrimSuffix(issuer, "/") + DiscoveryPath
+}
+
+// DiscoveryHandler: 설정 문서를 제공하는 핸들러
+func DiscoveryHandler(metadata *ProviderMetadata) http.Handler {
+	body, _ := json.Marshal(metadata)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		_, _ = w.Write(body)
+	})
+}
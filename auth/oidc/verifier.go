@@ -0,0 +1,253 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+var (
+	ErrIssuerMismatch         = errors.New("discovery issuer does not match")
+	ErrInvalidNonce           = errors.New("id token nonce does not match")
+	ErrInvalidAuthorizedParty = errors.New("id token azp is invalid")
+	ErrInvalidAccessTokenHash = errors.New("id token at_hash does not match access token")
+	ErrInvalidCodeHash        = errors.New("id token c_hash does not match authorization code")
+	ErrMissingRequiredClaim   = errors.New("id token is missing a required claim")
+	ErrUnsupportedSigningAlgs = errors.New("provider does not advertise a supported id token alg")
+)
+
+// Verifier: 외부 OpenID Provider 가 발급한 ID 토큰 검증기 (relying party)
+// TokenValidator[*IDTokenClaims] 를 구현하므로 v4jwt.ClaimsValidator 로 감싸 JwtMiddleware 에서 사용 가능
+type Verifier struct {
+	issuer   string
+	clientID string
+	keys     v4jwt.KeySet
+	algs     []string
+	client   *http.Client
+	metadata *ProviderMetadata
+}
+
+type VerifierOption func(*Verifier)
+
+// WithVerifierHTTPClient: discovery, JWKS 요청에 사용할 http.Client
+func WithVerifierHTTPClient(client *http.Client) VerifierOption {
+	return func(v *Verifier) {
+		v.client = client
+	}
+}
+
+// WithSupportedAlgs: 허용할 서명 알고리즘, 설정하지 않으면 discovery 문서의 값 사용
+func WithSupportedAlgs(algs ...string) VerifierOption {
+	return func(v *Verifier) {
+		v.algs = algs
+	}
+}
+
+// NewVerifier: issuer 의 discovery 문서와 JWKS 를 사용하는 Verifier 생성
+// discovery 문서의 issuer 가 요청한 issuer 와 정확히 일치해야 함
+func NewVerifier(ctx context.Context, issuer, clientID string, opts ...VerifierOption) (*Verifier, error) {
+	v := &Verifier{
+		issuer:   issuer,
+		clientID: clientID,
+		client:   http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	metadata, err := fetchMetadata(ctx, v.client, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.Issuer != issuer {
+		return nil, fmt.Errorf("%w: expected %q, got %q", ErrIssuerMismatch, issuer, metadata.Issuer)
+	}
+
+	if len(v.algs) == 0 {
+		v.algs = metadata.IDTokenSigningAlgValuesSupported
+	}
+	// none 알고리즘은 provider 가 광고하더라도 허용하지 않음
+	v.algs = withoutNone(v.algs)
+	if len(v.algs) == 0 {
+		return nil, ErrUnsupportedSigningAlgs
+	}
+
+	v.metadata = metadata
+	v.keys = v4jwt.NewRemoteKeySet(metadata.JWKSURI, v4jwt.WithHTTPClient(v.client))
+	return v, nil
+}
+
+// NewStaticVerifier: discovery 없이 주어진 KeySet 으로 검증하는 Verifier 생성
+func NewStaticVerifier(issuer, clientID string, keys v4jwt.KeySet, algs ...string) *Verifier {
+	if len(algs) == 0 {
+		algs = []string{"RS256"}
+	}
+	return &Verifier{
+		issuer:   issuer,
+		clientID: clientID,
+		keys:     keys,
+		algs:     withoutNone(algs),
+		client:   http.DefaultClient,
+	}
+}
+
+// Metadata: discovery 로 가져온 provider 설정 문서, NewStaticVerifier 로 생성한 경우 nil
+func (v *Verifier) Metadata() *ProviderMetadata {
+	return v.metadata
+}
+
+type verifyOptions struct {
+	nonce       string
+	accessToken string
+	code        string
+}
+
+type VerifyOption func(*verifyOptions)
+
+// WithNonce: 인가 요청에 사용한 nonce 와 일치하는지 확인
+func WithNonce(nonce string) VerifyOption {
+	return func(o *verifyOptions) {
+		o.nonce = nonce
+	}
+}
+
+// WithAccessToken: 함께 발급된 access 토큰으로 at_hash 확인 (at_hash 가 있는 경우)
+func WithAccessToken(accessToken string) VerifyOption {
+	return func(o *verifyOptions) {
+		o.accessToken = accessToken
+	}
+}
+
+// WithCode: 인가 코드로 c_hash 확인 (c_hash 가 있는 경우)
+func WithCode(code string) VerifyOption {
+	return func(o *verifyOptions) {
+		o.code = code
+	}
+}
+
+// ValidateToken: TokenValidator 구현, nonce / at_hash / c_hash 를 제외한 검증 수행
+func (v *Verifier) ValidateToken(tokenString string, claims *IDTokenClaims) (*IDTokenClaims, error) {
+	return v.verify(context.Background(), tokenString, claims, &verifyOptions{})
+}
+
+// Verify: ID 토큰 검증 (OpenID Connect Core 1.0 Section 3.1.3.7)
+func (v *Verifier) Verify(ctx context.Context, tokenString string, opts ...VerifyOption) (*IDTokenClaims, error) {
+	o := &verifyOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return v.verify(ctx, tokenString, &IDTokenClaims{}, o)
+}
+
+func (v *Verifier) verify(ctx context.Context, tokenString string, claims *IDTokenClaims, o *verifyOptions) (*IDTokenClaims, error) {
+	if claims == nil {
+		claims = &IDTokenClaims{}
+	}
+
+	parser := jwt.NewParser(jwt.WithValidMethods(v.algs))
+	token, err := parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		jwk, err := v.keys.Key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if jwk.Alg != "" && jwk.Alg != token.Method.Alg() {
+			return nil, fmt.Errorf("key %q is not for alg %s", kid, token.Method.Alg())
+		}
+		return jwk.PublicKey()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: exp", ErrMissingRequiredClaim)
+	}
+	if claims.IssuedAt == nil {
+		return nil, fmt.Errorf("%w: iat", ErrMissingRequiredClaim)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: sub", ErrMissingRequiredClaim)
+	}
+
+	if claims.Issuer != v.issuer {
+		return nil, v4jwt.ErrTokenInvalidIssuer
+	}
+	if !claims.VerifyAudience(v.clientID, true) {
+		return nil, v4jwt.ErrTokenInvalidAudience
+	}
+
+	// aud 가 여러개인 경우 azp 가 있어야 하며, azp 가 있다면 client_id 와 일치해야 함
+	if len(claims.Audience) > 1 && claims.AuthorizedParty == "" {
+		return nil, ErrInvalidAuthorizedParty
+	}
+	if claims.AuthorizedParty != "" && claims.AuthorizedParty != v.clientID {
+		return nil, ErrInvalidAuthorizedParty
+	}
+
+	if o.nonce != "" && subtle.ConstantTimeCompare([]byte(o.nonce), []byte(claims.Nonce)) != 1 {
+		return nil, ErrInvalidNonce
+	}
+
+	alg := token.Method.Alg()
+	if o.accessToken != "" && claims.AccessTokenHash != "" {
+		if !verifyTokenHash(claims.AccessTokenHash, o.accessToken, alg) {
+			return nil, ErrInvalidAccessTokenHash
+		}
+	}
+	if o.code != "" && claims.CodeHash != "" {
+		if !verifyTokenHash(claims.CodeHash, o.code, alg) {
+			return nil, ErrInvalidCodeHash
+		}
+	}
+
+	return claims, nil
+}
+
+func verifyTokenHash(expected, value, alg string) bool {
+	hash, err := TokenHash(value, alg)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(expected)) == 1
+}
+
+func fetchMetadata(ctx context.Context, client *http.Client, issuer string) (*ProviderMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, DiscoveryURL(issuer), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch discovery document: unexpected status %d", resp.StatusCode)
+	}
+
+	var metadata ProviderMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("decode discovery document: %w", err)
+	}
+	return &metadata, nil
+}
+
+func withoutNone(algs []string) []string {
+	filtered := make([]string, 0, len(algs))
+	for _, alg := range algs {
+		if !strings.EqualFold(alg, "none") {
+			filtered = append(filtered, alg)
+		}
+	}
+	return filtered
+}
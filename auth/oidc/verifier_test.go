@@ -0,0 +1,205 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider: discovery 문서와 JWKS 를 제공하는 테스트용 OpenID Provider
+type fakeProvider struct {
+	server  *httptest.Server
+	creator *v4jwt.Creator
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	config := v4jwt.NewKeyPairConfig(jwt.SigningMethodRS256, key, &key.PublicKey, v4jwt.WithKeyID("key-1"))
+	jwks, err := v4jwt.NewJWKS(config)
+	require.NoError(t, err)
+
+	p := &fakeProvider{creator: v4jwt.NewCreator(config)}
+	mux := http.NewServeMux()
+	mux.Handle("/jwks", v4jwt.JWKSHandler(jwks))
+	mux.HandleFunc(DiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		DiscoveryHandler(&ProviderMetadata{
+			Issuer:                           p.server.URL,
+			JWKSURI:                          p.server.URL + "/jwks",
+			IDTokenSigningAlgValuesSupported: []string{"RS256"},
+		}).ServeHTTP(w, r)
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *fakeProvider) claims() *IDTokenClaims {
+	return &IDTokenClaims{
+		Nonce: "nonce-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    p.server.URL,
+			Subject:   "user-1",
+			Audience:  jwt.ClaimStrings{"client-1"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+}
+
+func (p *fakeProvider) sign(t *testing.T, claims *IDTokenClaims) string {
+	t.Helper()
+	token, err := p.creator.CreateToken(claims)
+	require.NoError(t, err)
+	return token
+}
+
+func TestVerifier(t *testing.T) {
+	p := newFakeProvider(t)
+	verifier, err := NewVerifier(context.Background(), p.server.URL, "client-1")
+	require.NoError(t, err)
+
+	accessToken := "access-token"
+	atHash, err := TokenHash(accessToken, "RS256")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		modify        func(*IDTokenClaims)
+		opts          []VerifyOption
+		expectedError error
+	}{
+		{
+			name:   "유효한 ID 토큰",
+			modify: func(c *IDTokenClaims) { c.AccessTokenHash = atHash },
+			opts:   []VerifyOption{WithNonce("nonce-1"), WithAccessToken(accessToken)},
+		},
+		{
+			name:          "issuer 가 다른 경우",
+			modify:        func(c *IDTokenClaims) { c.Issuer = "https://other.example.com" },
+			expectedError: v4jwt.ErrTokenInvalidIssuer,
+		},
+		{
+			name:          "audience 에 client_id 가 없는 경우",
+			modify:        func(c *IDTokenClaims) { c.Audience = jwt.ClaimStrings{"client-2"} },
+			expectedError: v4jwt.ErrTokenInvalidAudience,
+		},
+		{
+			name:          "audience 가 여러개인데 azp 가 없는 경우",
+			modify:        func(c *IDTokenClaims) { c.Audience = jwt.ClaimStrings{"client-1", "client-2"} },
+			expectedError: ErrInvalidAuthorizedParty,
+		},
+		{
+			name:          "azp 가 client_id 와 다른 경우",
+			modify:        func(c *IDTokenClaims) { c.AuthorizedParty = "client-2" },
+			expectedError: ErrInvalidAuthorizedParty,
+		},
+		{
+			name:          "만료된 경우",
+			modify:        func(c *IDTokenClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute)) },
+			expectedError: v4jwt.ErrTokenExpired,
+		},
+		{
+			name:          "iat 가 없는 경우",
+			modify:        func(c *IDTokenClaims) { c.IssuedAt = nil },
+			expectedError: ErrMissingRequiredClaim,
+		},
+		{
+			name:          "nonce 가 다른 경우",
+			modify:        func(c *IDTokenClaims) {},
+			opts:          []VerifyOption{WithNonce("nonce-2")},
+			expectedError: ErrInvalidNonce,
+		},
+		{
+			name:          "at_hash 가 다른 경우",
+			modify:        func(c *IDTokenClaims) { c.AccessTokenHash = atHash },
+			opts:          []VerifyOption{WithAccessToken("other-access-token")},
+			expectedError: ErrInvalidAccessTokenHash,
+		},
+		{
+			name:          "c_hash 가 다른 경우",
+			modify:        func(c *IDTokenClaims) { c.CodeHash = atHash },
+			opts:          []VerifyOption{WithCode("code")},
+			expectedError: ErrInvalidCodeHash,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			claims := p.claims()
+			tc.modify(claims)
+
+			verified, err := verifier.Verify(context.Background(), p.sign(t, claims), tc.opts...)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", verified.Subject)
+		})
+	}
+
+	t.Run("다른 키로 서명된 경우", func(t *testing.T) {
+		other := newFakeProvider(t)
+		claims := p.claims()
+		_, err := verifier.Verify(context.Background(), other.sign(t, claims))
+		assert.ErrorIs(t, err, v4jwt.ErrTokenSignatureInvalid)
+	})
+
+	t.Run("HS256 으로 바꿔치기 한 경우", func(t *testing.T) {
+		creator := v4jwt.NewCreator(v4jwt.NewConfig(jwt.SigningMethodHS256, []byte("secret"), v4jwt.WithKeyID("key-1")))
+		token, err := creator.CreateToken(p.claims())
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(context.Background(), token)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewVerifier(t *testing.T) {
+	t.Run("discovery 문서의 issuer 가 다른 경우", func(t *testing.T) {
+		p := newFakeProvider(t)
+		_, err := NewVerifier(context.Background(), p.server.URL+"/", "client-1")
+		assert.ErrorIs(t, err, ErrIssuerMismatch)
+	})
+}
+
+func TestVerifierInMiddleware(t *testing.T) {
+	p := newFakeProvider(t)
+	verifier, err := NewVerifier(context.Background(), p.server.URL, "client-1")
+	require.NoError(t, err)
+
+	middleware := v4jwt.NewJwtMiddleware(
+		v4jwt.AuthHeaderExtractor,
+		v4jwt.ClaimsValidator[*IDTokenClaims](verifier),
+		nil,
+		&IDTokenClaims{},
+	)
+
+	handler := middleware.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := v4jwt.ClaimsFromContext[*IDTokenClaims](r.Context())
+		require.True(t, ok)
+		_, _ = w.Write([]byte(claims.Subject))
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+p.sign(t, p.claims()))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user-1", w.Body.String())
+}
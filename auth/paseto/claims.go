@@ -0,0 +1,79 @@
+package paseto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+// timeClaims: PASETO 에서 RFC 3339 문자열로 표현하는 등록 클레임
+// token.NumericDate 는 JWT 와 같은 초 단위 숫자로 직렬화하므로 변환하여 사용
+var timeClaims = []string{"exp", "nbf", "iat"}
+
+// marshalClaims: claims 의 exp, nbf, iat 를 RFC 3339 문자열로 바꾼 JSON
+func marshalClaims(claims token.Claims) ([]byte, error) {
+	b, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	registered := claims.GetRegisteredClaims()
+	dates := map[string]*token.NumericDate{
+		"exp": registered.ExpiresAt,
+		"nbf": registered.NotBefore,
+		"iat": registered.IssuedAt,
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for _, name := range timeClaims {
+		if _, ok := fields[name]; !ok || dates[name] == nil {
+			continue
+		}
+		value, err := json.Marshal(dates[name].UTC().Format(time.RFC3339))
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = value
+	}
+	return json.Marshal(fields)
+}
+
+// unmarshalClaims: RFC 3339 문자열인 exp, nbf, iat 를 숫자로 바꾼 뒤 claims 에 디코딩
+// 숫자로 표현된 값도 허용
+func unmarshalClaims(b []byte, claims token.Claims) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return fmt.Errorf("%w: could not JSON decode claims: %v", ErrTokenMalformed, err)
+	}
+
+	for _, name := range timeClaims {
+		var value string
+		if err := json.Unmarshal(fields[name], &value); err != nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Errorf("%w: invalid %s claim: %v", ErrTokenMalformed, name, err)
+		}
+		if t.Nanosecond() == 0 {
+			fields[name] = json.RawMessage(strconv.FormatInt(t.Unix(), 10))
+		} else {
+			fields[name] = json.RawMessage(strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', -1, 64))
+		}
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, claims); err != nil {
+		return fmt.Errorf("%w: could not JSON decode claims: %v", ErrTokenMalformed, err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package paseto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+)
+
+// 토큰 헤더 (version.purpose)
+const (
+	// Local: XChaCha20 + BLAKE2b 로 암호화한 대칭키 토큰
+	Local = "v4.local"
+	// Public: Ed25519 로 서명한 공개키 토큰, 페이로드는 암호화되지 않음
+	Public = "v4.public"
+)
+
+// LocalKeySize: v4.local 키 길이
+const LocalKeySize = 32
+
+// Config: 토큰 헤더와 키 설정
+// JWT 와 달리 알고리즘은 헤더로 고정되어 alg 를 바꿔치기 하는 공격이 불가능
+type Config struct {
+	header    string
+	secretKey []byte
+	// v4.public 의 서명키와 검증키
+	signingKey ed25519.PrivateKey
+	verifyKey  ed25519.PublicKey
+	keyID      string
+	// implicit: 토큰에 포함되지 않지만 발급, 검증 시 함께 인증하는 값 (implicit assertion)
+	implicit []byte
+}
+
+type ConfigOption func(*Config)
+
+// WithKeyID: 토큰 푸터의 kid 값 설정
+func WithKeyID(keyID string) ConfigOption {
+	return func(c *Config) {
+		c.keyID = keyID
+	}
+}
+
+// WithImplicitAssertion: 발급과 검증에 같은 값을 사용해야 하는 implicit assertion (테넌트 ID 등)
+func WithImplicitAssertion(assertion []byte) ConfigOption {
+	return func(c *Config) {
+		c.implicit = assertion
+	}
+}
+
+// NewLocalConfig: v4.local 설정, key 는 LocalKeySize 바이트
+func NewLocalConfig(key []byte, opts ...ConfigOption) *Config {
+	c := &Config{
+		header:    Local,
+		secretKey: key,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewPublicConfig: v4.public 설정
+// 검증만 하는 경우 signingKey 는 nil 로 설정
+func NewPublicConfig(signingKey ed25519.PrivateKey, verifyKey ed25519.PublicKey, opts ...ConfigOption) *Config {
+	c := &Config{
+		header:     Public,
+		signingKey: signingKey,
+		verifyKey:  verifyKey,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Header: 발급, 검증하는 토큰의 헤더 (Local 또는 Public)
+func (c *Config) Header() string {
+	return c.header
+}
+
+func (c *Config) KeyID() string {
+	return c.keyID
+}
+
+// GenerateLocalKey: v4.local 에 사용할 임의의 키 생성
+func GenerateLocalKey() ([]byte, error) {
+	key := make([]byte, LocalKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,61 @@
+package paseto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/nookcoder/go-boilerplate/auth/token"
+	"github.com/nookcoder/go-boilerplate/auth/token/tokentest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance(t *testing.T) {
+	key, err := GenerateLocalKey()
+	require.NoError(t, err)
+	otherKey, err := GenerateLocalKey()
+	require.NoError(t, err)
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPublic, otherPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// 다른 알고리즘은 다른 purpose 의 헤더
+	fixtures := []tokentest.Fixture[*Config]{
+		{
+			Name:     Local,
+			Config:   NewLocalConfig(key),
+			OtherKey: NewLocalConfig(otherKey),
+			OtherAlg: NewPublicConfig(private, public),
+		},
+		{
+			Name:     Public,
+			Config:   NewPublicConfig(private, public),
+			OtherKey: NewPublicConfig(otherPrivate, otherPublic),
+			OtherAlg: NewLocalConfig(key),
+		},
+	}
+
+	tokentest.RunFixtures(t, fixtures, func(f tokentest.Fixture[*Config]) tokentest.Backend {
+		return tokentest.Backend{
+			NewCreator: func(typ string) token.Creator {
+				return NewCreator(f.Config, WithTokenType(typ))
+			},
+			OtherKeyCreator: NewCreator(f.OtherKey),
+			OtherAlgCreator: NewCreator(f.OtherAlg),
+			NewValidator: func(o tokentest.ValidatorOptions) token.Validator[token.Claims] {
+				var opts []ValidatorOption
+				if o.Issuer != "" {
+					opts = append(opts, WithIssuer(o.Issuer))
+				}
+				if o.Audience != "" {
+					opts = append(opts, WithAudience(o.Audience))
+				}
+				if len(o.ExpectedTypes) > 0 {
+					opts = append(opts, WithExpectedTypes(o.ExpectedTypes...))
+				}
+				return NewValidator[token.Claims](f.Config, opts...)
+			},
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package paseto
+
+import (
+	"encoding/json"
+
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+// footer: 토큰 푸터, 인증되지만 v4.local 에서도 암호화되지 않음
+type footer struct {
+	// KeyID: 검증키를 선택하는 데 사용하는 PASETO 예약 푸터 클레임
+	KeyID string `json:"kid,omitempty"`
+	// Type: JWT 헤더의 typ 과 같은 용도의 토큰 종류 (예: at+jwt)
+	Type string `json:"typ,omitempty"`
+}
+
+type Creator struct {
+	*Config
+	tokenType string
+}
+
+type CreatorOption func(*Creator)
+
+// WithTokenType: 발급하는 토큰 푸터의 typ
+func WithTokenType(typ string) CreatorOption {
+	return func(c *Creator) {
+		c.tokenType = typ
+	}
+}
+
+func NewCreator(config *Config, opts ...CreatorOption) *Creator {
+	c := &Creator{
+		Config: config,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Creator) CreateToken(claims token.Claims) (string, error) {
+	return c.createToken(claims, c.tokenType)
+}
+
+// CreateTypedToken: 푸터의 typ 을 지정하여 토큰 생성
+func (c *Creator) CreateTypedToken(claims token.Claims, typ string) (string, error) {
+	return c.createToken(claims, typ)
+}
+
+// createToken: claims 가 nil 이면 빈 클레임으로 생성, kid 와 typ 이 모두 비어있으면 푸터 없이 생성
+func (c *Creator) createToken(claims token.Claims, typ string) (string, error) {
+	if claims == nil {
+		claims = &token.RegisteredClaims{}
+	}
+	message, err := marshalClaims(claims)
+	if err != nil {
+		return "", err
+	}
+
+	var f []byte
+	if c.Config.keyID != "" || typ != "" {
+		if f, err = json.Marshal(footer{KeyID: c.Config.keyID, Type: typ}); err != nil {
+			return "", err
+		}
+	}
+
+	if c.Config.header == Local {
+		return encrypt(c.Config.secretKey, message, f, c.Config.implicit)
+	}
+	return sign(c.Config.signingKey, message, f, c.Config.implicit)
+}
@@ -0,0 +1,29 @@
+package paseto
+
+import (
+	"errors"
+
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+var (
+	ErrInvalidKey = errors.New("key is invalid")
+)
+
+/**
+* 검증 에러는 token 패키지의 에러를 그대로 사용
+* token.ErrorHandler, token.ErrorClass 에서 JWT 백엔드와 같게 처리됨
+ */
+var (
+	ErrTokenMalformed        = token.ErrTokenMalformed
+	ErrTokenUnverifiable     = token.ErrTokenUnverifiable
+	ErrTokenSignatureInvalid = token.ErrTokenSignatureInvalid
+	ErrTokenExpired          = token.ErrTokenExpired
+	ErrTokenNotValidYet      = token.ErrTokenNotValidYet
+	ErrTokenUsedBeforeIssued = token.ErrTokenUsedBeforeIssued
+	ErrTokenInvalidIssuer    = token.ErrTokenInvalidIssuer
+	ErrTokenInvalidAudience  = token.ErrTokenInvalidAudience
+	ErrTokenInvalidClaims    = token.ErrTokenInvalidClaims
+	ErrTokenTypeMismatch     = token.ErrTokenTypeMismatch
+	ErrActionTokenMisuse     = token.ErrActionTokenMisuse
+)
@@ -0,0 +1,177 @@
+package paseto
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/chacha20"
+)
+
+// PASETO v4 (https://github.com/paseto-standard/paseto-spec/blob/master/docs/01-Protocol-Versions/Version4.md)
+const (
+	nonceSize = 32
+	macSize   = 32
+)
+
+var (
+	encryptionKeyInfo = []byte("paseto-encryption-key")
+	authKeyInfo       = []byte("paseto-auth-key-for-aead")
+)
+
+// encrypt: v4.local 암호화
+func encrypt(key, message, footer, implicit []byte) (string, error) {
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return encryptWithNonce(key, nonce, message, footer, implicit)
+}
+
+func encryptWithNonce(key, nonce, message, footer, implicit []byte) (string, error) {
+	if len(key) != LocalKeySize {
+		return "", fmt.Errorf("%w: %s key must be %d bytes", ErrInvalidKey, Local, LocalKeySize)
+	}
+
+	encryptionKey, counterNonce, authKey := splitKey(key, nonce)
+	cipher, err := chacha20.NewUnauthenticatedCipher(encryptionKey, counterNonce)
+	if err != nil {
+		return "", err
+	}
+	ciphertext := make([]byte, len(message))
+	cipher.XORKeyStream(ciphertext, message)
+
+	mac := localMAC(authKey, nonce, ciphertext, footer, implicit)
+
+	payload := make([]byte, 0, nonceSize+len(ciphertext)+macSize)
+	payload = append(append(append(payload, nonce...), ciphertext...), mac...)
+	return encode(Local, payload, footer), nil
+}
+
+// decrypt: v4.local 복호화, MAC 이 일치하지 않으면 ErrTokenSignatureInvalid
+func decrypt(key, payload, footer, implicit []byte) ([]byte, error) {
+	if len(key) != LocalKeySize {
+		return nil, fmt.Errorf("%w: %s key must be %d bytes", ErrInvalidKey, Local, LocalKeySize)
+	}
+	if len(payload) < nonceSize+macSize {
+		return nil, fmt.Errorf("%w: payload is too short", ErrTokenMalformed)
+	}
+
+	nonce := payload[:nonceSize]
+	ciphertext := payload[nonceSize : len(payload)-macSize]
+	mac := payload[len(payload)-macSize:]
+
+	encryptionKey, counterNonce, authKey := splitKey(key, nonce)
+	if !hmac.Equal(mac, localMAC(authKey, nonce, ciphertext, footer, implicit)) {
+		return nil, ErrTokenSignatureInvalid
+	}
+
+	cipher, err := chacha20.NewUnauthenticatedCipher(encryptionKey, counterNonce)
+	if err != nil {
+		return nil, err
+	}
+	message := make([]byte, len(ciphertext))
+	cipher.XORKeyStream(message, ciphertext)
+	return message, nil
+}
+
+// splitKey: 키와 nonce 로 암호화 키, XChaCha20 nonce, 인증 키 유도
+func splitKey(key, nonce []byte) ([]byte, []byte, []byte) {
+	tmp := keyedHash(56, key, encryptionKeyInfo, nonce)
+	authKey := keyedHash(32, key, authKeyInfo, nonce)
+	return tmp[:32], tmp[32:], authKey
+}
+
+func localMAC(authKey, nonce, ciphertext, footer, implicit []byte) []byte {
+	return keyedHash(macSize, authKey, pae([]byte(Local+"."), nonce, ciphertext, footer, implicit))
+}
+
+func keyedHash(size int, key []byte, data ...[]byte) []byte {
+	h, err := blake2b.New(size, key)
+	if err != nil {
+		// size 와 key 길이는 호출하는 쪽에서 고정
+		panic(err)
+	}
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// sign: v4.public 서명
+func sign(key ed25519.PrivateKey, message, footer, implicit []byte) (string, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return "", fmt.Errorf("%w: %s signing key must be an Ed25519 private key", ErrInvalidKey, Public)
+	}
+
+	signature := ed25519.Sign(key, pae([]byte(Public+"."), message, footer, implicit))
+	return encode(Public, append(append([]byte{}, message...), signature...), footer), nil
+}
+
+// verify: v4.public 서명 검증, 서명이 일치하지 않으면 ErrTokenSignatureInvalid
+func verify(key ed25519.PublicKey, payload, footer, implicit []byte) ([]byte, error) {
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: %s verify key must be an Ed25519 public key", ErrInvalidKey, Public)
+	}
+	if len(payload) < ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: payload is too short", ErrTokenMalformed)
+	}
+
+	message := payload[:len(payload)-ed25519.SignatureSize]
+	signature := payload[len(payload)-ed25519.SignatureSize:]
+	if !ed25519.Verify(key, pae([]byte(Public+"."), message, footer, implicit), signature) {
+		return nil, ErrTokenSignatureInvalid
+	}
+	return message, nil
+}
+
+// pae: Pre-Authentication Encoding, 조각의 개수와 각 조각의 길이를 앞에 붙여 연결
+func pae(pieces ...[]byte) []byte {
+	b := le64(nil, len(pieces))
+	for _, p := range pieces {
+		b = le64(b, len(p))
+		b = append(b, p...)
+	}
+	return b
+}
+
+// le64: 최상위 비트를 0 으로 하는 64비트 little endian 정수
+func le64(b []byte, n int) []byte {
+	return binary.LittleEndian.AppendUint64(b, uint64(n)&math.MaxInt64)
+}
+
+func encode(header string, payload, footer []byte) string {
+	s := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	if len(footer) > 0 {
+		s += "." + base64.RawURLEncoding.EncodeToString(footer)
+	}
+	return s
+}
+
+// decode: 토큰을 헤더 (version.purpose), 페이로드, 푸터로 분리
+func decode(tokenString string) (string, []byte, []byte, error) {
+	parts := strings.Split(tokenString, ".")
+	if len(parts) != 3 && len(parts) != 4 {
+		return "", nil, nil, fmt.Errorf("%w: token contains an invalid number of segments", ErrTokenMalformed)
+	}
+
+	header := parts[0] + "." + parts[1]
+	payload, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("%w: could not base64 decode payload: %v", ErrTokenMalformed, err)
+	}
+
+	var footer []byte
+	if len(parts) == 4 {
+		if footer, err = base64.RawURLEncoding.DecodeString(parts[3]); err != nil {
+			return "", nil, nil, fmt.Errorf("%w: could not base64 decode footer: %v", ErrTokenMalformed, err)
+		}
+	}
+	return header, payload, footer, nil
+}
@@ -0,0 +1,363 @@
+package paseto
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/nookcoder/go-boilerplate/auth/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPAE(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pieces   [][]byte
+		expected string
+	}{
+		{
+			name:     "조각 없음",
+			expected: "\x00\x00\x00\x00\x00\x00\x00\x00",
+		},
+		{
+			name:     "빈 조각",
+			pieces:   [][]byte{{}},
+			expected: "\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00",
+		},
+		{
+			name:     "조각 하나",
+			pieces:   [][]byte{[]byte("test")},
+			expected: "\x01\x00\x00\x00\x00\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00test",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, []byte(tc.expected), pae(tc.pieces...))
+		})
+	}
+}
+
+// PASETO v4 테스트 벡터 (https://github.com/paseto-standard/test-vectors) 의 공통 값
+const (
+	vectorLocalKey      = "707172737475767778797a7b7c7d7e7f808182838485868788898a8b8c8d8e8f"
+	vectorZeroNonce     = "0000000000000000000000000000000000000000000000000000000000000000"
+	vectorNonce         = "df654812bac492663825520ba2f6e67cf5ca5bdc13d4e7507a98cc4c2fcc3ad8"
+	vectorSecretKey     = "b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a37741eb9dbbbbc047c03fd70604e0071f0987e16b28b757225c11f00415d0e20b1a2"
+	vectorSecretPayload = `{"data":"this is a secret message","exp":"2022-01-01T00:00:00+00:00"}`
+	vectorHiddenPayload = `{"data":"this is a hidden message","exp":"2022-01-01T00:00:00+00:00"}`
+	vectorSignedPayload = `{"data":"this is a signed message","exp":"2022-01-01T00:00:00+00:00"}`
+	vectorKeyIDFooter   = `{"kid":"zVhMiPBP9fRf2snEcT7gFTioeA9COcNy9DfgL1W60haN"}`
+	vectorStringFooter  = "arbitrary-string-that-isn't-json"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// TestLocalVectors: PASETO 테스트 벡터 4-E-1 ~ 4-E-9
+func TestLocalVectors(t *testing.T) {
+	key := mustDecodeHex(t, vectorLocalKey)
+
+	testCases := []struct {
+		name     string
+		nonce    string
+		payload  string
+		footer   string
+		implicit string
+		token    string
+	}{
+		{
+			name:    "4-E-1",
+			nonce:   vectorZeroNonce,
+			payload: vectorSecretPayload,
+			token:   "v4.local.AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAr68PS4AXe7If_ZgesdkUMvSwscFlAl1pk5HC0e8kApeaqMfGo_7OpBnwJOAbY9V7WU6abu74MmcUE8YWAiaArVI8XJ5hOb_4v9RmDkneN0S92dx0OW4pgy7omxgf3S8c3LlQg",
+		},
+		{
+			name:    "4-E-2",
+			nonce:   vectorZeroNonce,
+			payload: vectorHiddenPayload,
+			token:   "v4.local.AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAQAr68PS4AXe7If_ZgesdkUMvS2csCgglvpk5HC0e8kApeaqMfGo_7OpBnwJOAbY9V7WU6abu74MmcUE8YWAiaArVI8XIemu9chy3WVKvRBfg6t8wwYHK0ArLxxfZP73W_vfwt5A",
+		},
+		{
+			name:    "4-E-3",
+			nonce:   vectorNonce,
+			payload: vectorSecretPayload,
+			token:   "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WkwMsYXw6FSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t6-tyebyWG6Ov7kKvBdkrrAJ837lKP3iDag2hzUPHuMKA",
+		},
+		{
+			name:    "4-E-4",
+			nonce:   vectorNonce,
+			payload: vectorHiddenPayload,
+			token:   "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WiA8rd3wgFSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t4gt6TiLm55vIH8c_lGxxZpE3AWlH4WTR0v45nsWoU3gQ",
+		},
+		{
+			name:    "4-E-5",
+			nonce:   vectorNonce,
+			payload: vectorSecretPayload,
+			footer:  vectorKeyIDFooter,
+			token: "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WkwMsYXw6FSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t4x-RMNXtQNbz7FvFZ_G-lFpk5RG3EOrwDL6CgDqcerSQ" +
+				".eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+		},
+		{
+			name:    "4-E-6",
+			nonce:   vectorNonce,
+			payload: vectorHiddenPayload,
+			footer:  vectorKeyIDFooter,
+			token: "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WiA8rd3wgFSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t6pWSA5HX2wjb3P-xLQg5K5feUCX4P2fpVK3ZLWFbMSxQ" +
+				".eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+		},
+		{
+			name:     "4-E-7",
+			nonce:    vectorNonce,
+			payload:  vectorSecretPayload,
+			footer:   vectorKeyIDFooter,
+			implicit: `{"test-vector":"4-E-7"}`,
+			token: "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WkwMsYXw6FSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t40KCCWLA7GYL9KFHzKlwY9_RnIfRrMQpueydLEAZGGcA" +
+				".eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+		},
+		{
+			name:     "4-E-8",
+			nonce:    vectorNonce,
+			payload:  vectorHiddenPayload,
+			footer:   vectorKeyIDFooter,
+			implicit: `{"test-vector":"4-E-8"}`,
+			token: "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WiA8rd3wgFSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t5uvqQbMGlLLNYBc7A6_x7oqnpUK5WLvj24eE4DVPDZjw" +
+				".eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+		},
+		{
+			name:     "4-E-9",
+			nonce:    vectorNonce,
+			payload:  vectorHiddenPayload,
+			footer:   vectorStringFooter,
+			implicit: `{"test-vector":"4-E-9"}`,
+			token: "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WiA8rd3wgFSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t6tybdlmnMwcDMw0YxA_gFSE_IUWl78aMtOepFYSWYfQA" +
+				".YXJiaXRyYXJ5LXN0cmluZy10aGF0LWlzbid0LWpzb24",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenString, err := encryptWithNonce(key, mustDecodeHex(t, tc.nonce), []byte(tc.payload), []byte(tc.footer), []byte(tc.implicit))
+			require.NoError(t, err)
+			assert.Equal(t, tc.token, tokenString)
+
+			header, payload, footer, err := decode(tc.token)
+			require.NoError(t, err)
+			assert.Equal(t, Local, header)
+			assert.Equal(t, tc.footer, string(footer))
+			got, err := decrypt(key, payload, footer, []byte(tc.implicit))
+			require.NoError(t, err)
+			assert.Equal(t, tc.payload, string(got))
+		})
+	}
+}
+
+// TestSign: PASETO 테스트 벡터 4-S-1 ~ 4-S-3
+func TestSign(t *testing.T) {
+	key := ed25519.PrivateKey(mustDecodeHex(t, vectorSecretKey))
+
+	testCases := []struct {
+		name     string
+		footer   string
+		implicit string
+		token    string
+	}{
+		{
+			name: "4-S-1",
+			token: "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9" +
+				"bg_XBBzds8lTZShVlwwKSgeKpLT3yukTw6JUz3W4h_ExsQV-P0V54zemZDcAxFaSeef1QlXEFtkqxT1ciiQEDA",
+		},
+		{
+			name:   "4-S-2",
+			footer: vectorKeyIDFooter,
+			token: "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9" +
+				"v3Jt8mx_TdM2ceTGoqwrh4yDFn0XsHvvV_D0DtwQxVrJEBMl0F2caAdgnpKlt4p7xBnx1HcO-SPo8FPp214HDw" +
+				".eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+		},
+		{
+			name:     "4-S-3",
+			footer:   vectorKeyIDFooter,
+			implicit: `{"test-vector":"4-S-3"}`,
+			token: "v4.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAyMi0wMS0wMVQwMDowMDowMCswMDowMCJ9" +
+				"NPWciuD3d0o5eXJXG5pJy-DiVEoyPYWs1YSTwWHNJq6DZD3je5gf-0M4JR9ipdUSJbIovzmBECeaWmaqcaP0DQ" +
+				".eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenString, err := sign(key, []byte(vectorSignedPayload), []byte(tc.footer), []byte(tc.implicit))
+			require.NoError(t, err)
+			assert.Equal(t, tc.token, tokenString)
+
+			header, payload, footer, err := decode(tc.token)
+			require.NoError(t, err)
+			assert.Equal(t, Public, header)
+			assert.Equal(t, tc.footer, string(footer))
+			got, err := verify(key.Public().(ed25519.PublicKey), payload, footer, []byte(tc.implicit))
+			require.NoError(t, err)
+			assert.Equal(t, vectorSignedPayload, string(got))
+		})
+	}
+}
+
+// TestFailureVectors: PASETO 테스트 벡터 4-F-1 ~ 4-F-5
+// 4-F-1 ~ 4-F-3 은 키의 헤더와 다른 토큰, 4-F-4, 4-F-5 는 base64 패딩이 있는 토큰
+func TestFailureVectors(t *testing.T) {
+	localKey := mustDecodeHex(t, vectorLocalKey)
+	secretKey := ed25519.PrivateKey(mustDecodeHex(t, vectorSecretKey))
+	localConfig := NewLocalConfig(localKey, WithImplicitAssertion([]byte(`{"test-vector":"4-F-2"}`)))
+	publicConfig := NewPublicConfig(nil, secretKey.Public().(ed25519.PublicKey), WithImplicitAssertion([]byte(`{"test-vector":"4-F-1"}`)))
+
+	localToken, err := encryptWithNonce(localKey, mustDecodeHex(t, vectorNonce), []byte(vectorHiddenPayload), []byte(vectorStringFooter), []byte(`{"test-vector":"4-F-1"}`))
+	require.NoError(t, err)
+	publicToken, err := sign(secretKey, []byte(`{"invalid":"this should never decode"}`), []byte(vectorStringFooter), []byte(`{"test-vector":"4-F-2"}`))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		config        *Config
+		token         string
+		expectedError error
+	}{
+		{
+			name:          "4-F-1",
+			config:        publicConfig,
+			token:         localToken,
+			expectedError: ErrTokenUnverifiable,
+		},
+		{
+			name:          "4-F-2",
+			config:        localConfig,
+			token:         publicToken,
+			expectedError: ErrTokenUnverifiable,
+		},
+		{
+			name:          "4-F-3",
+			config:        localConfig,
+			token:         "v3" + strings.TrimPrefix(localToken, "v4"),
+			expectedError: ErrTokenUnverifiable,
+		},
+		{
+			name:          "4-F-4",
+			config:        NewLocalConfig(localKey),
+			token:         "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WkwMsYXw6FSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t4x-RMNXtQNbz7FvFZ_G-lFpk5RG3EOrwDL6CgDqcerSQ==",
+			expectedError: ErrTokenMalformed,
+		},
+		{
+			name:   "4-F-5",
+			config: NewLocalConfig(localKey),
+			token: "v4.local.32VIErrEkmY4JVILovbmfPXKW9wT1OdQepjMTC_MOtjA4kiqw7_tcaOM5GNEcnTxl60WkwMsYXw6FSNb_UdJPXjpzm0KW9ojM5f4O2mRvE2IcweP-PRdoHjd5-RHCiExR1IK6t4x-RMNXtQNbz7FvFZ_G-lFpk5RG3EOrwDL6CgDqcerSQ==" +
+				".eyJraWQiOiJ6VmhNaVBCUDlmUmYyc25FY1Q3Z0ZUaW9lQTlDT2NOeTlEZmdMMVc2MGhhTiJ9",
+			expectedError: ErrTokenMalformed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewValidator[*token.RegisteredClaims](tc.config).ValidateToken(tc.token, &token.RegisteredClaims{})
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestLocal(t *testing.T) {
+	key, err := GenerateLocalKey()
+	require.NoError(t, err)
+	otherKey, err := GenerateLocalKey()
+	require.NoError(t, err)
+	message := []byte(`{"data":"this is a secret message"}`)
+
+	tokenString, err := encrypt(key, message, []byte(`{"typ":"at+jwt"}`), []byte("tenant-1"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(tokenString, Local+"."))
+	assert.NotContains(t, tokenString, "c2VjcmV0", "페이로드는 암호화되어야 함")
+
+	header, payload, footer, err := decode(tokenString)
+	require.NoError(t, err)
+	require.Equal(t, Local, header)
+
+	tampered := append([]byte{}, payload...)
+	tampered[nonceSize] ^= 1
+
+	testCases := []struct {
+		name          string
+		key           []byte
+		payload       []byte
+		footer        []byte
+		implicit      []byte
+		expectedError error
+	}{
+		{
+			name:     "정상 토큰",
+			key:      key,
+			payload:  payload,
+			footer:   footer,
+			implicit: []byte("tenant-1"),
+		},
+		{
+			name:          "다른 키",
+			key:           otherKey,
+			payload:       payload,
+			footer:        footer,
+			implicit:      []byte("tenant-1"),
+			expectedError: ErrTokenSignatureInvalid,
+		},
+		{
+			name:          "암호문 변조",
+			key:           key,
+			payload:       tampered,
+			footer:        footer,
+			implicit:      []byte("tenant-1"),
+			expectedError: ErrTokenSignatureInvalid,
+		},
+		{
+			name:          "푸터 변조",
+			key:           key,
+			payload:       payload,
+			footer:        []byte(`{"typ":"rt+jwt"}`),
+			implicit:      []byte("tenant-1"),
+			expectedError: ErrTokenSignatureInvalid,
+		},
+		{
+			name:          "implicit assertion 불일치",
+			key:           key,
+			payload:       payload,
+			footer:        footer,
+			implicit:      []byte("tenant-2"),
+			expectedError: ErrTokenSignatureInvalid,
+		},
+		{
+			name:          "짧은 페이로드",
+			key:           key,
+			payload:       payload[:nonceSize],
+			footer:        footer,
+			expectedError: ErrTokenMalformed,
+		},
+		{
+			name:          "잘못된 키 길이",
+			key:           key[:16],
+			payload:       payload,
+			footer:        footer,
+			expectedError: ErrInvalidKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := decrypt(tc.key, tc.payload, tc.footer, tc.implicit)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, message, got)
+		})
+	}
+}
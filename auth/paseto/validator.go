@@ -0,0 +1,144 @@
+package paseto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+type Validator[T token.Claims] struct {
+	*Config
+	validatorOptions
+}
+
+type ValidatorOption func(*validatorOptions)
+
+// validatorOptions: 클레임 타입과 무관한 Validator 옵션
+type validatorOptions struct {
+	expectedTypes []string
+	issuer        string
+	audience      string
+	leeway        time.Duration
+	timeFunc      func() time.Time
+}
+
+// WithIssuer: iss 클레임이 issuer 와 일치하는 토큰만 허용
+func WithIssuer(issuer string) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.issuer = issuer
+	}
+}
+
+// WithAudience: aud 클레임에 audience 가 포함된 토큰만 허용
+func WithAudience(audience string) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.audience = audience
+	}
+}
+
+// WithExpectedTypes: 푸터의 typ 이 types 중 하나인 토큰만 허용
+func WithExpectedTypes(types ...string) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.expectedTypes = types
+	}
+}
+
+// WithLeeway: exp, nbf, iat 검증에 허용할 시간 오차
+func WithLeeway(leeway time.Duration) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.leeway = leeway
+	}
+}
+
+// WithTimeFunc: 현재 시각 함수, 기본값은 time.Now
+func WithTimeFunc(timeFunc func() time.Time) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.timeFunc = timeFunc
+	}
+}
+
+func NewValidator[T token.Claims](config *Config, opts ...ValidatorOption) *Validator[T] {
+	v := &Validator[T]{
+		Config:           config,
+		validatorOptions: validatorOptions{timeFunc: time.Now},
+	}
+	for _, opt := range opts {
+		opt(&v.validatorOptions)
+	}
+	return v
+}
+
+// ValidateToken: 헤더, 서명 (v4.local 은 MAC), 푸터의 typ, 클레임 순서로 검증
+func (v *Validator[T]) ValidateToken(tokenString string, claims T) (T, error) {
+	var empty T
+
+	header, payload, f, err := decode(tokenString)
+	if err != nil {
+		return empty, err
+	}
+	if header != v.Config.header {
+		return empty, headerError(header, v.Config.header)
+	}
+
+	var message []byte
+	if v.Config.header == Local {
+		message, err = decrypt(v.Config.secretKey, payload, f, v.Config.implicit)
+	} else {
+		message, err = verify(v.Config.verifyKey, payload, f, v.Config.implicit)
+	}
+	if err != nil {
+		return empty, err
+	}
+
+	if err := v.validateType(f); err != nil {
+		return empty, err
+	}
+
+	if err := unmarshalClaims(message, claims); err != nil {
+		return empty, err
+	}
+	if err := v.validateClaims(claims.GetRegisteredClaims()); err != nil {
+		return empty, err
+	}
+	return claims, nil
+}
+
+// headerError: 다른 버전, 용도의 PASETO 토큰은 ErrTokenUnverifiable, PASETO 가 아니면 ErrTokenMalformed
+func headerError(header, expected string) error {
+	version, purpose, _ := strings.Cut(header, ".")
+	if strings.HasPrefix(version, "v") && (purpose == "local" || purpose == "public") {
+		return fmt.Errorf("%w: unexpected token header %q, expected %q", ErrTokenUnverifiable, header, expected)
+	}
+	return fmt.Errorf("%w: unknown token header %q", ErrTokenMalformed, header)
+}
+
+func (v *Validator[T]) validateType(b []byte) error {
+	var f footer
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &f); err != nil {
+			return fmt.Errorf("%w: could not JSON decode footer: %v", ErrTokenMalformed, err)
+		}
+	}
+	return token.CheckTokenType(v.expectedTypes, f.Type)
+}
+
+// validateClaims: golang-jwt 와 같이 exp, nbf, iat 가 없으면 검증하지 않음
+func (v *Validator[T]) validateClaims(claims *token.RegisteredClaims) error {
+	now := v.timeFunc()
+	switch {
+	case claims.ExpiresAt != nil && !now.Before(claims.ExpiresAt.Add(v.leeway)):
+		return ErrTokenExpired
+	case claims.NotBefore != nil && now.Add(v.leeway).Before(claims.NotBefore.Time):
+		return ErrTokenNotValidYet
+	case claims.IssuedAt != nil && now.Add(v.leeway).Before(claims.IssuedAt.Time):
+		return ErrTokenUsedBeforeIssued
+	case v.issuer != "" && claims.Issuer != v.issuer:
+		return ErrTokenInvalidIssuer
+	case v.audience != "" && !claims.Audience.Contains(v.audience):
+		return ErrTokenInvalidAudience
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package paseto
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nookcoder/go-boilerplate/auth/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimsFormat(t *testing.T) {
+	key, err := GenerateLocalKey()
+	require.NoError(t, err)
+	config := NewLocalConfig(key)
+	issuedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tokenString, err := NewCreator(config).CreateToken(&token.RegisteredClaims{
+		Subject:   "user-1",
+		IssuedAt:  token.NewNumericDate(issuedAt),
+		ExpiresAt: token.NewNumericDate(issuedAt.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+
+	_, payload, footer, err := decode(tokenString)
+	require.NoError(t, err)
+	assert.Empty(t, footer)
+	message, err := decrypt(key, payload, footer, nil)
+	require.NoError(t, err)
+
+	// exp, nbf, iat 는 PASETO 등록 클레임 형식인 RFC 3339 문자열
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(message, &fields))
+	assert.Equal(t, map[string]interface{}{
+		"sub": "user-1",
+		"iat": "2024-01-01T00:00:00Z",
+		"exp": "2024-01-01T01:00:00Z",
+	}, fields)
+
+	// 다른 구현체가 발급한 숫자, 시간대가 있는 값도 허용
+	claims := &token.RegisteredClaims{}
+	require.NoError(t, unmarshalClaims([]byte(`{"exp":"2024-01-01T09:00:00+09:00","iat":1704067200}`), claims))
+	assert.True(t, claims.ExpiresAt.Equal(issuedAt))
+	assert.True(t, claims.IssuedAt.Equal(issuedAt))
+
+	err = unmarshalClaims([]byte(`{"exp":"tomorrow"}`), claims)
+	assert.ErrorIs(t, err, ErrTokenMalformed)
+}
+
+func TestValidatorOptions(t *testing.T) {
+	key, err := GenerateLocalKey()
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		implicit      string
+		opts          []ValidatorOption
+		expiresAt     time.Time
+		expectedError error
+	}{
+		{
+			name:      "현재 시각 함수",
+			implicit:  "tenant-1",
+			opts:      []ValidatorOption{WithTimeFunc(func() time.Time { return now })},
+			expiresAt: now.Add(time.Second),
+		},
+		{
+			name:          "만료",
+			implicit:      "tenant-1",
+			opts:          []ValidatorOption{WithTimeFunc(func() time.Time { return now })},
+			expiresAt:     now,
+			expectedError: ErrTokenExpired,
+		},
+		{
+			name:      "leeway 이내 만료",
+			implicit:  "tenant-1",
+			opts:      []ValidatorOption{WithTimeFunc(func() time.Time { return now }), WithLeeway(time.Minute)},
+			expiresAt: now.Add(-30 * time.Second),
+		},
+		{
+			name:          "implicit assertion 불일치",
+			implicit:      "tenant-2",
+			opts:          []ValidatorOption{WithTimeFunc(func() time.Time { return now })},
+			expiresAt:     now.Add(time.Hour),
+			expectedError: ErrTokenSignatureInvalid,
+		},
+	}
+
+	validatorConfig := NewLocalConfig(key, WithImplicitAssertion([]byte("tenant-1")))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			creatorConfig := NewLocalConfig(key, WithImplicitAssertion([]byte(tc.implicit)))
+			tokenString, err := NewCreator(creatorConfig).CreateToken(&token.RegisteredClaims{
+				Subject:   "user-1",
+				ExpiresAt: token.NewNumericDate(tc.expiresAt),
+			})
+			require.NoError(t, err)
+
+			claims, err := NewValidator[*token.RegisteredClaims](validatorConfig, tc.opts...).ValidateToken(tokenString, &token.RegisteredClaims{})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", claims.Subject)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package token
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Claims: JWT, PASETO 등 토큰 라이브러리에 의존하지 않는 클레임
+// 애플리케이션 클레임은 RegisteredClaims 를 임베드하여 구현
+type Claims interface {
+	GetRegisteredClaims() *RegisteredClaims
+}
+
+// RegisteredClaims: RFC 7519 Section 4.1 의 등록 클레임
+// JSON 형식은 golang-jwt 의 RegisteredClaims 와 동일
+type RegisteredClaims struct {
+	Issuer    string       `json:"iss,omitempty"`
+	Subject   string       `json:"sub,omitempty"`
+	Audience  ClaimStrings `json:"aud,omitempty"`
+	ExpiresAt *NumericDate `json:"exp,omitempty"`
+	NotBefore *NumericDate `json:"nbf,omitempty"`
+	IssuedAt  *NumericDate `json:"iat,omitempty"`
+	ID        string       `json:"jti,omitempty"`
+}
+
+func (c *RegisteredClaims) GetRegisteredClaims() *RegisteredClaims {
+	return c
+}
+
+// NumericDate: 1970-01-01T00:00:00Z 부터의 초 (RFC 7519 Section 2)
+type NumericDate struct {
+	time.Time
+}
+
+// NewNumericDate: 초 단위로 버림
+func NewNumericDate(t time.Time) *NumericDate {
+	return &NumericDate{t.Truncate(time.Second)}
+}
+
+func (d NumericDate) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(d.Unix(), 10)), nil
+}
+
+// UnmarshalJSON: 소수점이 있는 값도 허용
+func (d *NumericDate) UnmarshalJSON(b []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return ErrTokenMalformed
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return ErrTokenMalformed
+	}
+	sec, frac := math.Modf(f)
+	d.Time = time.Unix(int64(sec), int64(frac*1e9))
+	return nil
+}
+
+// ClaimStrings: 문자열 하나 또는 문자열 배열인 클레임 (aud 등)
+// 직렬화는 항상 배열
+type ClaimStrings []string
+
+func (s *ClaimStrings) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return ErrTokenMalformed
+	}
+
+	switch v := v.(type) {
+	case string:
+		*s = ClaimStrings{v}
+	case []interface{}:
+		values := make(ClaimStrings, 0, len(v))
+		for _, e := range v {
+			str, ok := e.(string)
+			if !ok {
+				return ErrTokenMalformed
+			}
+			values = append(values, str)
+		}
+		*s = values
+	case nil:
+		*s = nil
+	default:
+		return ErrTokenMalformed
+	}
+	return nil
+}
+
+// Contains: audience 가 포함되어 있는지 확인
+func (s ClaimStrings) Contains(audience string) bool {
+	for _, a := range s {
+		if a == audience {
+			return true
+		}
+	}
+	return false
+}
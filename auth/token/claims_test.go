@@ -0,0 +1,66 @@
+package token
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisteredClaimsJSON(t *testing.T) {
+	issuedAt := time.Unix(1700000000, 0)
+	claims := &RegisteredClaims{
+		Issuer:    "issuer",
+		Subject:   "user-1",
+		Audience:  ClaimStrings{"api"},
+		ExpiresAt: NewNumericDate(issuedAt.Add(time.Hour)),
+		IssuedAt:  NewNumericDate(issuedAt.Add(500 * time.Millisecond)),
+	}
+
+	b, err := json.Marshal(claims)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"iss":"issuer","sub":"user-1","aud":["api"],"exp":1700003600,"iat":1700000000}`, string(b))
+
+	decoded := &RegisteredClaims{}
+	require.NoError(t, json.Unmarshal(b, decoded))
+	assert.Equal(t, claims.Audience, decoded.Audience)
+	assert.True(t, claims.ExpiresAt.Equal(decoded.ExpiresAt.Time))
+}
+
+func TestClaimStringsUnmarshal(t *testing.T) {
+	testCases := []struct {
+		name     string
+		json     string
+		expected ClaimStrings
+		wantErr  bool
+	}{
+		{name: "문자열", json: `"api"`, expected: ClaimStrings{"api"}},
+		{name: "배열", json: `["api","web"]`, expected: ClaimStrings{"api", "web"}},
+		{name: "null", json: `null`},
+		{name: "숫자 배열", json: `[1]`, wantErr: true},
+		{name: "숫자", json: `1`, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var s ClaimStrings
+			err := json.Unmarshal([]byte(tc.json), &s)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrTokenMalformed)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, s)
+		})
+	}
+}
+
+func TestNumericDateUnmarshal(t *testing.T) {
+	var d NumericDate
+	require.NoError(t, json.Unmarshal([]byte(`1700000000.5`), &d))
+	assert.Equal(t, time.Unix(1700000000, 5e8), d.Time)
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"2024-01-01"`), &d), ErrTokenMalformed)
+}
@@ -0,0 +1,71 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// DefaultErrorHandler: v4jwt.DefaultErrorHandler 와 같은 상태 코드, 메시지로 응답
+// 토큰이 없거나 Authorization 헤더 형식이 잘못된 경우는 WWW-Authenticate 와 함께 401 응답 (RFC 6750 Section 3)
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	status, message, challenge := errorResponse(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	if challenge != "" {
+		w.Header().Set("WWW-Authenticate", challenge)
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Message string `json:"message"`
+	}{Message: message})
+}
+
+// ProblemErrorHandler: DefaultErrorHandler 와 같은 상태 코드를 RFC 9457 problem details 형식으로 응답
+func ProblemErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	status, message, challenge := errorResponse(err)
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	if challenge != "" {
+		w.Header().Set("WWW-Authenticate", challenge)
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail"`
+	}{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: message,
+	})
+}
+
+// errorResponse: 에러에 해당하는 상태 코드, 메시지와 WWW-Authenticate 헤더 값
+func errorResponse(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, ErrTokenSignatureInvalid):
+		return http.StatusUnauthorized, "invalid token signature", ""
+	case errors.Is(err, ErrTokenExpired) || errors.Is(err, ErrTokenNotValidYet):
+		return http.StatusUnauthorized, "token is expired or not valid yet", ""
+	case errors.Is(err, ErrTokenMalformed):
+		return http.StatusUnauthorized, "invalid token format", ""
+	case errors.Is(err, ErrKeyNotFound):
+		return http.StatusUnauthorized, "untrusted token issuer", ""
+	case errors.Is(err, ErrTokenInvalidIssuer) || errors.Is(err, ErrTokenInvalidAudience):
+		return http.StatusUnauthorized, "invalid token claims", ""
+	case errors.Is(err, ErrTokenTypeMismatch):
+		return http.StatusUnauthorized, "invalid token type", ""
+	case errors.Is(err, ErrTokenMissing):
+		// 인증 정보가 없는 요청에는 에러 코드를 포함하지 않음
+		return http.StatusUnauthorized, "missing token", "Bearer"
+	case errors.Is(err, ErrAuthHeaderMalformed):
+		return http.StatusUnauthorized, "invalid authorization header", `Bearer error="invalid_request"`
+	default:
+		return http.StatusInternalServerError, "internal server error in jwt", ""
+	}
+}
@@ -0,0 +1,56 @@
+package token
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Extractor: 요청에서 토큰을 추출하는 함수 타입
+// 헤더나 쿠키가 없는 경우 에러로 인식하지 않고 빈 문자열 반환
+type Extractor func(r *http.Request) (string, error)
+
+// ErrAuthHeaderMalformed: Authorization 헤더가 Bearer <token> 형식이 아닌 경우
+var ErrAuthHeaderMalformed = errors.New("Authorization header format must be Bearer {token}")
+
+// AuthHeaderExtractor: Authorization: Bearer <token> 에서 token 추출
+func AuthHeaderExtractor(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", nil
+	}
+
+	authHeaderParts := strings.Split(authHeader, " ")
+	if len(authHeaderParts) != 2 || strings.ToLower(authHeaderParts[0]) != "bearer" {
+		return "", ErrAuthHeaderMalformed
+	}
+
+	return authHeaderParts[1], nil
+}
+
+// CookieExtractor: 쿠키에서 토큰 추출
+func CookieExtractor(cookieName string) Extractor {
+	return func(r *http.Request) (string, error) {
+		cookie, err := r.Cookie(cookieName)
+		// 쿠키가 없는 경우 빈 문자열 반환
+		if err != nil {
+			return "", nil
+		}
+
+		return cookie.Value, nil
+	}
+}
+
+// MultiExtractor: 순서대로 시도하여 처음으로 찾은 토큰 반환
+// 추출 중 에러가 발생하면 다음 extractor 를 시도하지 않음
+func MultiExtractor(extractors ...Extractor) Extractor {
+	return func(r *http.Request) (string, error) {
+		for _, extractor := range extractors {
+			token, err := extractor(r)
+			if err != nil || token != "" {
+				return token, err
+			}
+		}
+		return "", nil
+	}
+}
@@ -0,0 +1,130 @@
+package token
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+)
+
+// ContextKey: Middleware 가 검증한 클레임을 저장하는 컨텍스트 키
+type ContextKey struct{}
+
+// RequestMatcher: 요청이 조건에 해당하는지 판단하는 함수
+type RequestMatcher func(r *http.Request) bool
+
+// Middleware: 백엔드에 의존하지 않는 토큰 검증 미들웨어
+type Middleware struct {
+	extractor    Extractor
+	validator    Validator[Claims]
+	errorHandler ErrorHandler
+	claims       Claims
+	exclusions   []RequestMatcher
+}
+
+type MiddlewareOption func(*Middleware)
+
+// WithExclusions: matcher 중 하나라도 해당하는 요청은 토큰 검증 없이 통과 (health check 등)
+func WithExclusions(matchers ...RequestMatcher) MiddlewareOption {
+	return func(m *Middleware) {
+		m.exclusions = append(m.exclusions, matchers...)
+	}
+}
+
+// NewMiddleware: claims 는 요청마다 같은 타입의 새 인스턴스로 복제되어 사용됨
+// extractor 가 nil 이면 AuthHeaderExtractor, errorHandler 가 nil 이면 DefaultErrorHandler 사용
+func NewMiddleware(extractor Extractor, validator Validator[Claims], errorHandler ErrorHandler, claims Claims, opts ...MiddlewareOption) *Middleware {
+	if extractor == nil {
+		extractor = AuthHeaderExtractor
+	}
+	if errorHandler == nil {
+		errorHandler = DefaultErrorHandler
+	}
+
+	m := &Middleware{
+		extractor:    extractor,
+		validator:    validator,
+		errorHandler: errorHandler,
+		claims:       claims,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// OPTIONS 요청과 제외된 요청은 통과
+		if r.Method == http.MethodOptions || m.excluded(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, err := m.authenticate(r)
+		if err != nil {
+			m.errorHandler(w, r, err)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), ContextKey{}, claims)
+		next.ServeHTTP(w, r.Clone(ctx))
+	})
+}
+
+func (m *Middleware) excluded(r *http.Request) bool {
+	for _, match := range m.exclusions {
+		if match(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Middleware) authenticate(r *http.Request) (Claims, error) {
+	tokenString, err := m.extractor(r)
+	if err != nil {
+		return nil, err
+	}
+	if tokenString == "" {
+		return nil, ErrTokenMissing
+	}
+	return m.validator.ValidateToken(tokenString, NewClaims(m.claims))
+}
+
+// ClaimsFromContext: Middleware 가 저장한 클레임 조회
+func ClaimsFromContext[T Claims](ctx context.Context) (T, bool) {
+	claims, ok := ctx.Value(ContextKey{}).(T)
+	return claims, ok
+}
+
+// NewClaims: 동시 요청이 같은 클레임 인스턴스에 디코딩 하지 않도록 같은 타입의 빈 인스턴스 생성
+// prototype 이 nil 이면 *RegisteredClaims
+func NewClaims(prototype Claims) Claims {
+	if prototype == nil {
+		return &RegisteredClaims{}
+	}
+
+	t := reflect.TypeOf(prototype)
+	if t.Kind() == reflect.Pointer {
+		return reflect.New(t.Elem()).Interface().(Claims)
+	}
+	return prototype
+}
+
+// ValidatorOf: Validator[T] 를 Middleware 에서 사용하는 Validator[Claims] 로 변환
+// 미들웨어의 클레임 타입이 T 와 다른 경우 ErrTokenInvalidClaims 반환
+func ValidatorOf[T Claims](validator Validator[T]) Validator[Claims] {
+	return &claimsValidator[T]{validator: validator}
+}
+
+type claimsValidator[T Claims] struct {
+	validator Validator[T]
+}
+
+func (v *claimsValidator[T]) ValidateToken(tokenString string, claims Claims) (Claims, error) {
+	c, ok := claims.(T)
+	if !ok {
+		return nil, ErrTokenInvalidClaims
+	}
+	return v.validator.ValidateToken(tokenString, c)
+}
@@ -0,0 +1,119 @@
+package token
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubValidator struct {
+	err error
+}
+
+func (v stubValidator) ValidateToken(tokenString string, claims Claims) (Claims, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	claims.GetRegisteredClaims().Subject = tokenString
+	return claims, nil
+}
+
+func TestMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext[*RegisteredClaims](r.Context())
+		if ok {
+			_, _ = w.Write([]byte(claims.Subject))
+		}
+	})
+
+	testCases := []struct {
+		name           string
+		validator      Validator[Claims]
+		path           string
+		authorization  string
+		expectedStatus int
+		expectedBody   string
+		// expectedChallenge: WWW-Authenticate 헤더 값
+		expectedChallenge string
+	}{
+		{
+			name:           "정상 토큰",
+			validator:      stubValidator{},
+			path:           "/",
+			authorization:  "Bearer user-1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "user-1",
+		},
+		{
+			name:           "제외된 경로",
+			validator:      stubValidator{},
+			path:           "/health",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "만료",
+			validator:      stubValidator{err: ErrTokenExpired},
+			path:           "/",
+			authorization:  "Bearer user-1",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"message":"token is expired or not valid yet"}`,
+		},
+		{
+			name:              "토큰 없음",
+			validator:         stubValidator{},
+			path:              "/",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedBody:      `{"message":"missing token"}`,
+			expectedChallenge: "Bearer",
+		},
+		{
+			name:              "Bearer 형식이 아님",
+			validator:         stubValidator{},
+			path:              "/",
+			authorization:     "user-1",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedBody:      `{"message":"invalid authorization header"}`,
+			expectedChallenge: `Bearer error="invalid_request"`,
+		},
+		{
+			name:           "알 수 없는 에러",
+			validator:      stubValidator{err: errors.New("boom")},
+			path:           "/",
+			authorization:  "Bearer user-1",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"internal server error in jwt"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMiddleware(nil, tc.validator, nil, &RegisteredClaims{}, WithExclusions(func(r *http.Request) bool {
+				return r.URL.Path == "/health"
+			}))
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rec := httptest.NewRecorder()
+			m.Handler(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedBody, strings.TrimSpace(rec.Body.String()))
+			assert.Equal(t, tc.expectedChallenge, rec.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestNewClaims(t *testing.T) {
+	prototype := &RegisteredClaims{Subject: "prototype"}
+	claims := NewClaims(prototype)
+	assert.IsType(t, &RegisteredClaims{}, claims)
+	assert.NotSame(t, prototype, claims)
+	assert.Empty(t, claims.GetRegisteredClaims().Subject)
+
+	assert.IsType(t, &RegisteredClaims{}, NewClaims(nil))
+}
@@ -0,0 +1,92 @@
+package token
+
+import (
+	"errors"
+)
+
+// Creator: Claims 로 토큰을 생성
+type Creator interface {
+	CreateToken(claims Claims) (string, error)
+}
+
+// Validator: 토큰을 검증하고 claims 에 디코딩
+type Validator[T Claims] interface {
+	ValidateToken(tokenString string, claims T) (T, error)
+}
+
+type Manager[T Claims] interface {
+	Creator
+	Validator[T]
+}
+
+// TokenManager: 백엔드의 Creator 와 Validator 를 하나의 Manager 로 사용
+type TokenManager[T Claims] struct {
+	Creator   Creator
+	Validator Validator[T]
+}
+
+// NewManager: 토큰 형식 (JWT, PASETO) 에 관계없이 같은 Manager 로 사용
+func NewManager[T Claims](creator Creator, validator Validator[T]) Manager[T] {
+	return &TokenManager[T]{
+		Creator:   creator,
+		Validator: validator,
+	}
+}
+
+func (m *TokenManager[T]) CreateToken(claims Claims) (string, error) {
+	return m.Creator.CreateToken(claims)
+}
+
+func (m *TokenManager[T]) ValidateToken(tokenString string, claims T) (T, error) {
+	return m.Validator.ValidateToken(tokenString, claims)
+}
+
+// 백엔드에 관계없이 errors.Is 로 확인할 수 있는 검증 에러
+// 백엔드는 라이브러리의 에러와 함께 감싸서 반환하므로 라이브러리의 에러로도 확인 가능
+var (
+	ErrTokenMissing          = errors.New("missing token")
+	ErrTokenMalformed        = errors.New("token is malformed")
+	ErrTokenUnverifiable     = errors.New("token is unverifiable")
+	ErrTokenSignatureInvalid = errors.New("token signature is invalid")
+	ErrTokenExpired          = errors.New("token is expired")
+	ErrTokenNotValidYet      = errors.New("token is not valid yet")
+	ErrTokenUsedBeforeIssued = errors.New("token used before issued")
+	ErrTokenInvalidIssuer    = errors.New("token has invalid issuer")
+	ErrTokenInvalidAudience  = errors.New("token has invalid audience")
+	ErrTokenInvalidClaims    = errors.New("token has invalid claims")
+	ErrTokenTypeMismatch     = errors.New("unexpected token type")
+	ErrKeyNotFound           = errors.New("key not found")
+)
+
+// ErrorClass: 메트릭, 로그에 사용하는 에러 분류
+// v4jwt.ErrorClass 와 같은 값을 사용
+func ErrorClass(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrTokenMissing):
+		return "missing"
+	case errors.Is(err, ErrTokenMalformed):
+		return "malformed"
+	case errors.Is(err, ErrTokenSignatureInvalid):
+		return "signature"
+	case errors.Is(err, ErrTokenExpired):
+		return "expired"
+	case errors.Is(err, ErrTokenNotValidYet) || errors.Is(err, ErrTokenUsedBeforeIssued):
+		return "not_valid_yet"
+	case errors.Is(err, ErrTokenTypeMismatch):
+		return "type"
+	case errors.Is(err, ErrKeyNotFound):
+		return "unknown_key"
+	case errors.Is(err, ErrTokenInvalidIssuer):
+		return "issuer"
+	case errors.Is(err, ErrTokenInvalidAudience):
+		return "audience"
+	case errors.Is(err, ErrTokenUnverifiable):
+		return "unverifiable"
+	case errors.Is(err, ErrTokenInvalidClaims):
+		return "claims"
+	default:
+		return "other"
+	}
+}
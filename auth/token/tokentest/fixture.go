@@ -0,0 +1,56 @@
+package tokentest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Fixture: 적합성 테스트를 실행할 백엔드 설정, C 는 백엔드의 Config 타입
+type Fixture[C any] struct {
+	Name   string
+	Config C
+	// OtherKey: Config 와 같은 알고리즘, 다른 키의 설정
+	OtherKey C
+	// OtherAlg: Config 와 다른 알고리즘의 설정
+	OtherAlg C
+}
+
+// RunFixtures: fixture 마다 newBackend 로 만든 Backend 로 Run 실행
+func RunFixtures[C any](t *testing.T, fixtures []Fixture[C], newBackend func(f Fixture[C]) Backend) {
+	for _, f := range fixtures {
+		t.Run(f.Name, func(t *testing.T) {
+			Run(t, newBackend(f))
+		})
+	}
+}
+
+// JWTFixtures: JWT 백엔드가 공통으로 사용하는 HS256, ES256 설정
+// newConfig 는 alg 와 키로 Config 를 생성, HS* 인 경우 signingKey 는 []byte secret 이고 verifyKey 는 nil
+func JWTFixtures[C any](t *testing.T, newConfig func(alg string, signingKey crypto.PrivateKey, verifyKey crypto.PublicKey) C) []Fixture[C] {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	secret := []byte("conformance-test-secret-0123456789")
+
+	return []Fixture[C]{
+		{
+			Name:     "HS256",
+			Config:   newConfig("HS256", secret, nil),
+			OtherKey: newConfig("HS256", []byte("another-test-secret-0123456789abcd"), nil),
+			OtherAlg: newConfig("HS384", secret, nil),
+		},
+		{
+			Name:     "ES256",
+			Config:   newConfig("ES256", key, &key.PublicKey),
+			OtherKey: newConfig("ES256", otherKey, &otherKey.PublicKey),
+			OtherAlg: newConfig("HS256", secret, nil),
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ActionTokenType: 1회용 액션 토큰의 typ
+// 모든 백엔드의 Validator 는 이 typ 의 토큰을 거부하므로 access 토큰으로 사용할 수 없음
+const ActionTokenType = "action+jwt"
+
+// ErrActionTokenMisuse: errors.Is 로 ErrTokenTypeMismatch 와도 일치
+var ErrActionTokenMisuse = fmt.Errorf("%w: action token cannot be used as an access token", ErrTokenTypeMismatch)
+
+// CheckTokenType: 액션 토큰은 항상 거부하고, expected 가 있으면 typ 이 그 중 하나인지 확인
+func CheckTokenType(expected []string, typ string) error {
+	if normalizeTokenType(typ) == ActionTokenType {
+		return ErrActionTokenMisuse
+	}
+	if len(expected) > 0 && !MatchTokenType(expected, typ) {
+		return fmt.Errorf("%w: %q", ErrTokenTypeMismatch, typ)
+	}
+	return nil
+}
+
+// MatchTokenType: typ 이 expected 중 하나인지 대소문자를 구분하지 않고 "application/" 접두어를 생략하여 비교
+// (RFC 9068 Section 4, RFC 7515 Section 4.1.9)
+func MatchTokenType(expected []string, typ string) bool {
+	typ = normalizeTokenType(typ)
+	if typ == "" {
+		return false
+	}
+	for _, e := range expected {
+		if normalizeTokenType(e) == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeTokenType(typ string) string {
+	typ = strings.ToLower(typ)
+	return strings.TrimPrefix(typ, "application/")
+}
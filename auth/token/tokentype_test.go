@@ -0,0 +1,57 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTokenType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected []string
+		typ      string
+		match    bool
+	}{
+		{name: "일치", expected: []string{"at+jwt"}, typ: "at+jwt", match: true},
+		{name: "대소문자 무시", expected: []string{"at+jwt"}, typ: "AT+JWT", match: true},
+		{name: "application/ 접두어 생략", expected: []string{"at+jwt"}, typ: "application/at+jwt", match: true},
+		{name: "여러 값 중 일치", expected: []string{"at+jwt", "JWT"}, typ: "jwt", match: true},
+		{name: "불일치", expected: []string{"at+jwt"}, typ: "rt+jwt"},
+		{name: "typ 없음", expected: []string{"at+jwt"}, typ: ""},
+		{name: "application/ 만 있는 typ", expected: []string{""}, typ: "application/"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.match, MatchTokenType(tc.expected, tc.typ))
+		})
+	}
+}
+
+func TestCheckTokenType(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expected      []string
+		typ           string
+		expectedError error
+	}{
+		{name: "expected 없음", typ: "rt+jwt"},
+		{name: "expected 와 일치", expected: []string{"at+jwt"}, typ: "at+jwt"},
+		{name: "expected 와 불일치", expected: []string{"at+jwt"}, typ: "rt+jwt", expectedError: ErrTokenTypeMismatch},
+		{name: "액션 토큰", typ: ActionTokenType, expectedError: ErrActionTokenMisuse},
+		{name: "expected 에 포함된 액션 토큰", expected: []string{ActionTokenType}, typ: "application/ACTION+JWT", expectedError: ErrActionTokenMisuse},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckTokenType(tc.expected, tc.typ)
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.ErrorIs(t, err, ErrTokenTypeMismatch)
+		})
+	}
+}
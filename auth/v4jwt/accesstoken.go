@@ -0,0 +1,100 @@
+package v4jwt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	ErrAccessTokenClaimMissing = errors.New("missing required access token claim")
+
+	ErrMissingIssuer    = fmt.Errorf("%w: iss", ErrAccessTokenClaimMissing)
+	ErrMissingExpiresAt = fmt.Errorf("%w: exp", ErrAccessTokenClaimMissing)
+	ErrMissingAudience  = fmt.Errorf("%w: aud", ErrAccessTokenClaimMissing)
+	ErrMissingSubject   = fmt.Errorf("%w: sub", ErrAccessTokenClaimMissing)
+	ErrMissingClientID  = fmt.Errorf("%w: client_id", ErrAccessTokenClaimMissing)
+	ErrMissingIssuedAt  = fmt.Errorf("%w: iat", ErrAccessTokenClaimMissing)
+	ErrMissingTokenID   = fmt.Errorf("%w: jti", ErrAccessTokenClaimMissing)
+)
+
+// AccessTokenClaims: JWT access 토큰 프로필 클레임 (RFC 9068 Section 2.2)
+// scope 는 인가 요청에 scope 가 있었던 경우에만 포함하므로 (SHOULD) 필수로 검사하지 않음
+type AccessTokenClaims struct {
+	ClientID string           `json:"client_id"`
+	Scope    string           `json:"scope,omitempty"`
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	ACR      string           `json:"acr,omitempty"`
+	AMR      []string         `json:"amr,omitempty"`
+	// RFC 9068 Section 2.2.3.1, SCIM Core Schema 의 사용자 속성
+	Groups       []string `json:"groups,omitempty"`
+	Roles        []string `json:"roles,omitempty"`
+	Entitlements []string `json:"entitlements,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Valid: 시간 클레임 검증 후 필수 클레임 확인
+func (c *AccessTokenClaims) Valid() error {
+	if err := c.RegisteredClaims.Valid(); err != nil {
+		return err
+	}
+
+	switch {
+	case c.Issuer == "":
+		return ErrMissingIssuer
+	case c.ExpiresAt == nil:
+		return ErrMissingExpiresAt
+	case len(c.Audience) == 0:
+		return ErrMissingAudience
+	case c.Subject == "":
+		return ErrMissingSubject
+	case c.ClientID == "":
+		return ErrMissingClientID
+	case c.IssuedAt == nil:
+		return ErrMissingIssuedAt
+	case c.ID == "":
+		return ErrMissingTokenID
+	}
+	return nil
+}
+
+// Scopes: 공백으로 구분된 scope 클레임을 목록으로 반환
+func (c *AccessTokenClaims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// HasScope: scope 클레임에 scope 가 포함되어 있는지 확인
+func (c *AccessTokenClaims) HasScope(scope string) bool {
+	return containsString(c.Scopes(), scope)
+}
+
+// CreateAccessToken: typ 을 at+jwt 로 설정하여 RFC 9068 access 토큰 발급
+// iat, jti 가 비어있으면 채워서 발급하며, 필수 클레임이 누락되면 발급하지 않음
+func (c *Creator) CreateAccessToken(claims *AccessTokenClaims) (string, error) {
+	if claims.IssuedAt == nil {
+		claims.IssuedAt = jwt.NewNumericDate(jwt.TimeFunc())
+	}
+	if claims.ID == "" {
+		jti, err := NewTokenID()
+		if err != nil {
+			return "", err
+		}
+		claims.ID = jti
+	}
+	if err := claims.Valid(); err != nil {
+		return "", err
+	}
+	return c.createToken(claims, AccessTokenType)
+}
+
+// NewAccessTokenValidator: RFC 9068 Section 4 에 따라 typ, iss, aud 를 확인하는 Validator
+func NewAccessTokenValidator(config *Config, issuer, audience string, opts ...ValidatorOption) *Validator[*AccessTokenClaims] {
+	opts = append([]ValidatorOption{
+		WithExpectedTypes(AccessTokenType),
+		WithIssuer(issuer),
+		WithAudience(audience),
+	}, opts...)
+	return NewValidator[*AccessTokenClaims](config, opts...)
+}
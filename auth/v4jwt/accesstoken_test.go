@@ -0,0 +1,119 @@
+package v4jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAccessTokenClaims() *AccessTokenClaims {
+	now := time.Now()
+	return &AccessTokenClaims{
+		ClientID: "spa",
+		Scope:    "read write",
+		Roles:    []string{"admin"},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "https://auth.example.com",
+			Subject:   "user-1",
+			Audience:  jwt.ClaimStrings{"https://api.example.com"},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        "at-1",
+		},
+	}
+}
+
+func TestAccessTokenProfile(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	config := NewKeyPairConfig(jwt.SigningMethodES256, key, &key.PublicKey, WithKeyID("key-1"))
+	creator := NewCreator(config)
+	validator := NewAccessTokenValidator(config, "https://auth.example.com", "https://api.example.com")
+
+	t.Run("발급 및 검증", func(t *testing.T) {
+		claims := newAccessTokenClaims()
+		claims.ID = ""
+		claims.IssuedAt = nil
+
+		token, err := creator.CreateAccessToken(claims)
+		require.NoError(t, err)
+
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &AccessTokenClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, AccessTokenType, parsed.Header["typ"])
+
+		got, err := validator.ValidateToken(token, &AccessTokenClaims{})
+		require.NoError(t, err)
+		assert.NotEmpty(t, got.ID)
+		assert.NotNil(t, got.IssuedAt)
+		assert.Equal(t, []string{"admin"}, got.Roles)
+		assert.True(t, got.HasScope("write"))
+		assert.False(t, got.HasScope("delete"))
+	})
+
+	t.Run("필수 클레임 누락", func(t *testing.T) {
+		testCases := []struct {
+			name          string
+			modify        func(c *AccessTokenClaims)
+			expectedError error
+		}{
+			{name: "iss", modify: func(c *AccessTokenClaims) { c.Issuer = "" }, expectedError: ErrMissingIssuer},
+			{name: "exp", modify: func(c *AccessTokenClaims) { c.ExpiresAt = nil }, expectedError: ErrMissingExpiresAt},
+			{name: "aud", modify: func(c *AccessTokenClaims) { c.Audience = nil }, expectedError: ErrMissingAudience},
+			{name: "sub", modify: func(c *AccessTokenClaims) { c.Subject = "" }, expectedError: ErrMissingSubject},
+			{name: "client_id", modify: func(c *AccessTokenClaims) { c.ClientID = "" }, expectedError: ErrMissingClientID},
+			{name: "iat", modify: func(c *AccessTokenClaims) { c.IssuedAt = nil }, expectedError: ErrMissingIssuedAt},
+			{name: "jti", modify: func(c *AccessTokenClaims) { c.ID = "" }, expectedError: ErrMissingTokenID},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				claims := newAccessTokenClaims()
+				tc.modify(claims)
+
+				// 발급 단계에서 채워지지 않는 클레임은 발급 거부
+				if tc.name != "iat" && tc.name != "jti" {
+					_, err := creator.CreateAccessToken(claims)
+					assert.ErrorIs(t, err, tc.expectedError)
+				}
+
+				token, err := creator.CreateTypedToken(claims, AccessTokenType)
+				require.NoError(t, err)
+				_, err = validator.ValidateToken(token, &AccessTokenClaims{})
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.ErrorIs(t, err, ErrAccessTokenClaimMissing)
+			})
+		}
+	})
+
+	t.Run("typ 이 at+jwt 가 아니면 거부", func(t *testing.T) {
+		token, err := creator.CreateToken(newAccessTokenClaims())
+		require.NoError(t, err)
+		_, err = validator.ValidateToken(token, &AccessTokenClaims{})
+		assert.ErrorIs(t, err, ErrTokenTypeMismatch)
+	})
+
+	t.Run("issuer 불일치", func(t *testing.T) {
+		claims := newAccessTokenClaims()
+		claims.Issuer = "https://evil.example.com"
+		token, err := creator.CreateAccessToken(claims)
+		require.NoError(t, err)
+		_, err = validator.ValidateToken(token, &AccessTokenClaims{})
+		assert.ErrorIs(t, err, ErrTokenInvalidIssuer)
+	})
+
+	t.Run("audience 불일치", func(t *testing.T) {
+		claims := newAccessTokenClaims()
+		claims.Audience = jwt.ClaimStrings{"https://other.example.com"}
+		token, err := creator.CreateAccessToken(claims)
+		require.NoError(t, err)
+		_, err = validator.ValidateToken(token, &AccessTokenClaims{})
+		assert.ErrorIs(t, err, ErrTokenInvalidAudience)
+	})
+}
@@ -0,0 +1,237 @@
+package v4jwt
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+// ActionTokenType: 액션 토큰 헤더의 typ
+// 일반 Validator 는 이 typ 의 토큰을 거부하므로 access 토큰으로 사용할 수 없음
+const ActionTokenType = token.ActionTokenType
+
+// 기본 제공 purpose, 애플리케이션에서 임의의 값 사용 가능
+const (
+	PurposeEmailVerification = "email_verification"
+	PurposePasswordReset     = "password_reset"
+	PurposeInvitation        = "invitation"
+	PurposeMagicLink         = "magic_link"
+)
+
+const defaultActionTokenTTL = 15 * time.Minute
+
+// actionBindingLabel: HMAC secret 에서 바인딩 키를 유도할 때 사용하는 값, 서명키와 같은 키를 사용하지 않도록 함
+const actionBindingLabel = "v4jwt action token binding"
+
+var (
+	ErrActionTokenInvalid    = errors.New("invalid action token")
+	ErrActionTokenBinding    = errors.New("action token binding does not match")
+	ErrActionTokenMisuse     = token.ErrActionTokenMisuse
+	ErrActionPurposeRequired = errors.New("action token purpose and audience are required")
+	ErrActionBindingKey      = errors.New("action token binding requires WithActionBindingKey or an HMAC config")
+)
+
+// ActionClaims: 액션 토큰 클레임
+type ActionClaims struct {
+	Purpose string `json:"purpose"`
+	// Binding: 발급 시점 바인딩 값의 HMAC-SHA256 (base64url)
+	Binding string `json:"bnd,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Action: 발급할 액션 토큰
+type Action struct {
+	Purpose  string
+	Audience string
+	Subject  string
+	// Binding: 토큰을 바인딩할 사용자의 현재 값 (비밀번호 해시, 이메일 등)
+	// 값이 바뀌면 토큰이 무효화되며, 비어있으면 바인딩하지 않음
+	Binding string
+	// TTL: 비어있으면 ActionTokens 의 기본값 사용
+	TTL time.Duration
+}
+
+// ActionVerification: 액션 토큰 검증 조건
+type ActionVerification struct {
+	Purpose  string
+	Audience string
+	// Binding: subject 의 현재 바인딩 값 조회, 발급 시 Binding 을 설정한 경우 필수
+	Binding func(ctx context.Context, subject string) (string, error)
+}
+
+// ActionTokens: 이메일 인증, 비밀번호 재설정, 초대, 매직 링크용 1회용 토큰
+// purpose, audience 가 일치하고 아직 사용되지 않은 토큰만 허용
+type ActionTokens struct {
+	creator *Creator
+	issuer  string
+	ttl     time.Duration
+	replay  ReplayCache
+	now     func() time.Time
+	// bindingKey: 바인딩 값의 HMAC 키, 토큰을 본 사람이 바인딩 값 (비밀번호 해시 등) 을 대입해 확인할 수 없도록 함
+	bindingKey []byte
+}
+
+type ActionOption func(*ActionTokens)
+
+// WithActionIssuer: 액션 토큰의 iss 클레임, 설정하면 검증 시에도 확인
+func WithActionIssuer(issuer string) ActionOption {
+	return func(a *ActionTokens) {
+		a.issuer = issuer
+	}
+}
+
+// WithActionTTL: 액션 토큰 기본 유효 시간
+func WithActionTTL(ttl time.Duration) ActionOption {
+	return func(a *ActionTokens) {
+		a.ttl = ttl
+	}
+}
+
+// WithActionReplayCache: 사용된 액션 토큰의 jti 를 기록할 ReplayCache
+func WithActionReplayCache(cache ReplayCache) ActionOption {
+	return func(a *ActionTokens) {
+		a.replay = cache
+	}
+}
+
+// WithActionBindingKey: Binding 의 HMAC 키
+// 설정하지 않으면 HMAC secret 에서 유도하며, 비대칭 키, 키 링 설정에서 Binding 을 사용하려면 필수
+func WithActionBindingKey(key []byte) ActionOption {
+	return func(a *ActionTokens) {
+		a.bindingKey = key
+	}
+}
+
+func NewActionTokens(config *Config, opts ...ActionOption) *ActionTokens {
+	a := &ActionTokens{
+		creator: NewCreator(config),
+		ttl:     defaultActionTokenTTL,
+		replay:  NewMemoryReplayCache(),
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	// 키 링은 secret 이 교체되면 발급한 토큰의 바인딩을 확인할 수 없으므로 유도하지 않음
+	if a.bindingKey == nil && config.keyRing == nil && len(config.secretKey) > 0 {
+		mac := hmac.New(sha256.New, config.secretKey)
+		mac.Write([]byte(actionBindingLabel))
+		a.bindingKey = mac.Sum(nil)
+	}
+	return a
+}
+
+// Create: 액션 토큰 발급
+func (a *ActionTokens) Create(action *Action) (string, error) {
+	if action.Purpose == "" || action.Audience == "" {
+		return "", ErrActionPurposeRequired
+	}
+
+	jti, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	ttl := action.TTL
+	if ttl == 0 {
+		ttl = a.ttl
+	}
+
+	now := a.now()
+	claims := &ActionClaims{
+		Purpose: action.Purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    a.issuer,
+			Subject:   action.Subject,
+			Audience:  jwt.ClaimStrings{action.Audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        jti,
+		},
+	}
+	if action.Binding != "" {
+		if claims.Binding, err = a.bindingHash(action.Binding); err != nil {
+			return "", err
+		}
+	}
+
+	return a.creator.createToken(claims, ActionTokenType)
+}
+
+// Verify: 액션 토큰을 검증하고 사용 처리
+// 검증에 성공한 토큰은 다시 사용할 수 없음 (ErrTokenReplayed)
+func (a *ActionTokens) Verify(ctx context.Context, tokenString string, expect *ActionVerification) (*ActionClaims, error) {
+	if expect.Purpose == "" || expect.Audience == "" {
+		return nil, ErrActionPurposeRequired
+	}
+
+	claims := &ActionClaims{}
+	config := a.creator.Config
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{config.method.Alg()}))
+	_, err := parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != ActionTokenType {
+			return nil, fmt.Errorf("%w: typ must be %s", ErrActionTokenInvalid, ActionTokenType)
+		}
+		return config.verifyKeyFor(token)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Purpose != expect.Purpose {
+		return nil, fmt.Errorf("%w: purpose does not match", ErrActionTokenInvalid)
+	}
+	if !claims.VerifyAudience(expect.Audience, true) {
+		return nil, fmt.Errorf("%w: audience does not match", ErrActionTokenInvalid)
+	}
+	if a.issuer != "" && !claims.VerifyIssuer(a.issuer, true) {
+		return nil, fmt.Errorf("%w: issuer does not match", ErrActionTokenInvalid)
+	}
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: jti and exp are required", ErrActionTokenInvalid)
+	}
+
+	if claims.Binding != "" {
+		if expect.Binding == nil {
+			return nil, ErrActionTokenBinding
+		}
+		current, err := expect.Binding(ctx, claims.Subject)
+		if err != nil {
+			return nil, err
+		}
+		binding, err := a.bindingHash(current)
+		if err != nil {
+			return nil, err
+		}
+		if subtle.ConstantTimeCompare([]byte(claims.Binding), []byte(binding)) != 1 {
+			return nil, ErrActionTokenBinding
+		}
+	}
+
+	ok, err := a.replay.Use(ctx, claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrTokenReplayed
+	}
+
+	return claims, nil
+}
+
+func (a *ActionTokens) bindingHash(value string) (string, error) {
+	if len(a.bindingKey) == 0 {
+		return "", ErrActionBindingKey
+	}
+	mac := hmac.New(sha256.New, a.bindingKey)
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,185 @@
+package v4jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionTokens(t *testing.T) {
+	ctx := context.Background()
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"))
+	actions := NewActionTokens(config, WithActionIssuer("https://app.example.com"))
+
+	resetAction := &Action{
+		Purpose:  PurposePasswordReset,
+		Audience: "https://app.example.com/reset-password",
+		Subject:  "user-1",
+		Binding:  "$2a$10$current-password-hash",
+	}
+	resetVerification := &ActionVerification{
+		Purpose:  PurposePasswordReset,
+		Audience: "https://app.example.com/reset-password",
+		Binding: func(ctx context.Context, subject string) (string, error) {
+			return "$2a$10$current-password-hash", nil
+		},
+	}
+
+	t.Run("한 번만 사용 가능", func(t *testing.T) {
+		token, err := actions.Create(resetAction)
+		require.NoError(t, err)
+
+		claims, err := actions.Verify(ctx, token, resetVerification)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+		assert.Equal(t, PurposePasswordReset, claims.Purpose)
+
+		_, err = actions.Verify(ctx, token, resetVerification)
+		assert.ErrorIs(t, err, ErrTokenReplayed)
+	})
+
+	t.Run("바인딩 값이 바뀌면 거부", func(t *testing.T) {
+		token, err := actions.Create(resetAction)
+		require.NoError(t, err)
+
+		_, err = actions.Verify(ctx, token, &ActionVerification{
+			Purpose:  resetVerification.Purpose,
+			Audience: resetVerification.Audience,
+			Binding: func(ctx context.Context, subject string) (string, error) {
+				return "$2a$10$new-password-hash", nil
+			},
+		})
+		assert.ErrorIs(t, err, ErrActionTokenBinding)
+
+		// 바인딩 불일치로 거부된 토큰은 사용 처리되지 않음
+		_, err = actions.Verify(ctx, token, resetVerification)
+		assert.NoError(t, err)
+	})
+
+	t.Run("purpose 가 다르면 거부", func(t *testing.T) {
+		token, err := actions.Create(&Action{
+			Purpose:  PurposeEmailVerification,
+			Audience: resetAction.Audience,
+			Subject:  "user-1",
+		})
+		require.NoError(t, err)
+
+		_, err = actions.Verify(ctx, token, resetVerification)
+		assert.ErrorIs(t, err, ErrActionTokenInvalid)
+	})
+
+	t.Run("audience 가 다르면 거부", func(t *testing.T) {
+		token, err := actions.Create(resetAction)
+		require.NoError(t, err)
+
+		_, err = actions.Verify(ctx, token, &ActionVerification{
+			Purpose:  PurposePasswordReset,
+			Audience: "https://app.example.com/invite",
+			Binding:  resetVerification.Binding,
+		})
+		assert.ErrorIs(t, err, ErrActionTokenInvalid)
+	})
+
+	t.Run("만료된 토큰 거부", func(t *testing.T) {
+		token, err := actions.Create(&Action{
+			Purpose:  PurposeMagicLink,
+			Audience: "https://app.example.com/login",
+			Subject:  "user-1",
+			TTL:      -time.Minute,
+		})
+		require.NoError(t, err)
+
+		_, err = actions.Verify(ctx, token, &ActionVerification{
+			Purpose:  PurposeMagicLink,
+			Audience: "https://app.example.com/login",
+		})
+		assert.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("purpose, audience 는 필수", func(t *testing.T) {
+		_, err := actions.Create(&Action{Subject: "user-1"})
+		assert.ErrorIs(t, err, ErrActionPurposeRequired)
+	})
+
+	t.Run("액션 토큰은 access 토큰으로 사용 불가", func(t *testing.T) {
+		token, err := actions.Create(resetAction)
+		require.NoError(t, err)
+
+		_, err = NewValidator[*jwt.RegisteredClaims](config).ValidateToken(token, &jwt.RegisteredClaims{})
+		assert.ErrorIs(t, err, ErrActionTokenMisuse)
+	})
+
+	t.Run("access 토큰은 액션 토큰으로 사용 불가", func(t *testing.T) {
+		token, err := NewCreator(config).CreateToken(&ActionClaims{
+			Purpose: PurposePasswordReset,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "user-1",
+				Audience:  jwt.ClaimStrings{resetAction.Audience},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+				ID:        "access-1",
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = actions.Verify(ctx, token, resetVerification)
+		assert.ErrorIs(t, err, ErrActionTokenInvalid)
+	})
+
+	t.Run("바인딩은 서버 키로 계산", func(t *testing.T) {
+		token, err := actions.Create(resetAction)
+		require.NoError(t, err)
+		claims := &ActionClaims{}
+		_, _, err = new(jwt.Parser).ParseUnverified(token, claims)
+		require.NoError(t, err)
+
+		// 토큰의 bnd 로 바인딩 값을 대입해 확인할 수 없어야 함
+		sum := sha256.Sum256([]byte(resetAction.Binding))
+		assert.NotEqual(t, base64.RawURLEncoding.EncodeToString(sum[:]), claims.Binding)
+
+		other := NewActionTokens(config, WithActionBindingKey([]byte("other-binding-key")))
+		_, err = other.Verify(ctx, token, resetVerification)
+		assert.ErrorIs(t, err, ErrActionTokenBinding)
+	})
+}
+
+func TestActionTokensBindingKey(t *testing.T) {
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	config := NewKeyPairConfig(jwt.SigningMethodES256, key, &key.PublicKey)
+	action := &Action{
+		Purpose:  PurposePasswordReset,
+		Audience: "https://app.example.com/reset-password",
+		Subject:  "user-1",
+		Binding:  "$2a$10$current-password-hash",
+	}
+
+	t.Run("비대칭 키 설정은 바인딩 키 필요", func(t *testing.T) {
+		_, err := NewActionTokens(config).Create(action)
+		assert.ErrorIs(t, err, ErrActionBindingKey)
+	})
+
+	t.Run("바인딩 키 설정", func(t *testing.T) {
+		actions := NewActionTokens(config, WithActionBindingKey([]byte("binding-key")))
+		token, err := actions.Create(action)
+		require.NoError(t, err)
+
+		_, err = actions.Verify(ctx, token, &ActionVerification{
+			Purpose:  action.Purpose,
+			Audience: action.Audience,
+			Binding: func(ctx context.Context, subject string) (string, error) {
+				return action.Binding, nil
+			},
+		})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,147 @@
+package v4jwt
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// 감사 로그 이벤트 (slog 메시지)
+// 토큰 원문은 기록하지 않으며, 토큰을 식별해야 하는 경우 TokenFingerprint 사용
+const (
+	AuditTokenIssued      = "jwt.token_issued"
+	AuditValidationFailed = "jwt.validation_failed"
+	AuditTokenRevoked     = "jwt.token_revoked"
+	AuditKeyRotated       = "jwt.key_rotated"
+	// AuditSecretRefreshFailed: SecretKeyRing 이 secret 을 다시 가져오지 못한 경우
+	AuditSecretRefreshFailed = "jwt.secret_refresh_failed"
+)
+
+// WithAuditLogger: 이 설정을 사용하는 Creator 의 발급, Validator 의 폐기 이벤트 기록
+func WithAuditLogger(logger *slog.Logger) ConfigOption {
+	return func(c *Config) {
+		c.auditLogger = logger
+	}
+}
+
+// WithRequestAuditLogger: JwtMiddleware 의 인증 실패 이벤트 기록
+func WithRequestAuditLogger(logger *slog.Logger) MiddlewareOption {
+	return func(m *JwtMiddleware) {
+		m.auditLogger = logger
+	}
+}
+
+// WithKeySetAuditLogger: RemoteKeySet 을 다시 가져왔을 때 kid 목록이 바뀌면 키 교체 이벤트 기록
+func WithKeySetAuditLogger(logger *slog.Logger) RemoteKeySetOption {
+	return func(s *RemoteKeySet) {
+		s.auditLogger = logger
+	}
+}
+
+// TokenFingerprint: 로그에서 토큰을 구분하기 위한 SHA-256 해시 앞 12 바이트 (base64url)
+// 해시에서 토큰을 복원할 수 없으므로 로그에 남겨도 토큰이 노출되지 않음
+func TokenFingerprint(tokenString string) string {
+	sum := sha256.Sum256([]byte(tokenString))
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// auditTokenIssued: 발급한 토큰의 등록 클레임과 kid 기록
+func auditTokenIssued(logger *slog.Logger, tokenString, kid string) {
+	claims, err := registeredClaimsFromString(tokenString)
+	if err != nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("sub", claims.Subject),
+		slog.String("jti", claims.ID),
+		slog.Any("aud", []string(claims.Audience)),
+		slog.String("kid", kid),
+	}
+	if claims.ExpiresAt != nil {
+		attrs = append(attrs, slog.Time("exp", claims.ExpiresAt.Time))
+	}
+	logger.LogAttrs(context.Background(), slog.LevelInfo, AuditTokenIssued, attrs...)
+}
+
+// auditValidationFailed: 인증 실패 사유 분류와 요청 정보 기록
+func auditValidationFailed(logger *slog.Logger, r *http.Request, tokenString string, err error) {
+	attrs := []slog.Attr{
+		slog.String("reason", ErrorClass(err)),
+		slog.String("remote_addr", r.RemoteAddr),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+	}
+	if tokenString != "" {
+		attrs = append(attrs, slog.String("token_fingerprint", TokenFingerprint(tokenString)))
+	}
+	logger.LogAttrs(r.Context(), slog.LevelWarn, AuditValidationFailed, attrs...)
+}
+
+func auditTokenRevoked(logger *slog.Logger, attr slog.Attr) {
+	logger.LogAttrs(context.Background(), slog.LevelInfo, AuditTokenRevoked, attr)
+}
+
+// auditKeyRotated: 추가, 삭제된 kid 가 있는 경우에만 기록
+func auditKeyRotated(logger *slog.Logger, url string, previous, current *JWKS, fetchedAt time.Time) {
+	if previous == nil {
+		return
+	}
+	added, removed := diffKeyIDs(previous, current)
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	logger.LogAttrs(context.Background(), slog.LevelInfo, AuditKeyRotated,
+		slog.String("jwks_uri", url),
+		slog.Any("added", added),
+		slog.Any("removed", removed),
+		slog.Time("fetched_at", fetchedAt),
+	)
+}
+
+// auditSecretRotated: SecretKeyRing 의 현재 버전이 바뀐 경우 기록
+func auditSecretRotated(logger *slog.Logger, previous, current string, removed []string, rotatedAt time.Time) {
+	logger.LogAttrs(context.Background(), slog.LevelInfo, AuditKeyRotated,
+		slog.Any("added", []string{current}),
+		slog.Any("removed", removed),
+		slog.String("retired", previous),
+		slog.Time("fetched_at", rotatedAt),
+	)
+}
+
+func auditSecretRefreshFailed(logger *slog.Logger, err error) {
+	logger.LogAttrs(context.Background(), slog.LevelWarn, AuditSecretRefreshFailed, slog.String("error", err.Error()))
+}
+
+func diffKeyIDs(previous, current *JWKS) (added, removed []string) {
+	before := make(map[string]bool, len(previous.Keys))
+	for _, k := range previous.Keys {
+		before[k.Kid] = true
+	}
+	after := make(map[string]bool, len(current.Keys))
+	for _, k := range current.Keys {
+		after[k.Kid] = true
+		if !before[k.Kid] {
+			added = append(added, k.Kid)
+		}
+	}
+	for kid := range before {
+		if !after[kid] {
+			removed = append(removed, kid)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// registeredClaimsFromString: 서명 검증 없이 토큰의 등록 클레임 조회
+func registeredClaimsFromString(tokenString string) (*jwt.RegisteredClaims, error) {
+	return registeredClaimsOf(&jwt.Token{Raw: tokenString})
+}
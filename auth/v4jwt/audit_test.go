@@ -0,0 +1,140 @@
+package v4jwt
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditRecords: JSON 핸들러로 기록한 로그를 이벤트 단위로 분리
+func auditRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestAuditLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"), WithKeyID("key-1"), WithAuditLogger(logger))
+	validator := NewValidator[jwt.Claims](config)
+	exp := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	token, err := NewCreator(config).CreateToken(&jwt.RegisteredClaims{
+		Subject:   "user-1",
+		ID:        "jti-1",
+		Audience:  jwt.ClaimStrings{"api"},
+		ExpiresAt: jwt.NewNumericDate(exp),
+	})
+	require.NoError(t, err)
+
+	forged, err := NewCreator(NewConfig(jwt.SigningMethodHS256, []byte("other"))).CreateToken(&jwt.RegisteredClaims{Subject: "user-1"})
+	require.NoError(t, err)
+
+	m := NewJwtMiddleware(AuthHeaderExtractor, validator, nil, &jwt.RegisteredClaims{}, WithRequestAuditLogger(logger))
+	h := m.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("Authorization", "Bearer "+forged)
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	validator.Invalidate("jti-1")
+	validator.InvalidateToken(token)
+
+	records := auditRecords(t, &buf)
+	require.Len(t, records, 4)
+
+	t.Run("토큰 발급", func(t *testing.T) {
+		issued := records[0]
+		assert.Equal(t, AuditTokenIssued, issued["msg"])
+		assert.Equal(t, "user-1", issued["sub"])
+		assert.Equal(t, "jti-1", issued["jti"])
+		assert.Equal(t, []interface{}{"api"}, issued["aud"])
+		assert.Equal(t, "key-1", issued["kid"])
+		issuedExp, err := time.Parse(time.RFC3339Nano, issued["exp"].(string))
+		require.NoError(t, err)
+		assert.True(t, exp.Equal(issuedExp))
+	})
+
+	t.Run("검증 실패", func(t *testing.T) {
+		failed := records[1]
+		assert.Equal(t, AuditValidationFailed, failed["msg"])
+		assert.Equal(t, "WARN", failed["level"])
+		assert.Equal(t, "signature", failed["reason"])
+		assert.Equal(t, "10.0.0.1:1234", failed["remote_addr"])
+		assert.Equal(t, "/orders", failed["path"])
+		assert.Equal(t, TokenFingerprint(forged), failed["token_fingerprint"])
+	})
+
+	t.Run("토큰 폐기", func(t *testing.T) {
+		assert.Equal(t, AuditTokenRevoked, records[2]["msg"])
+		assert.Equal(t, "jti-1", records[2]["jti"])
+		assert.Equal(t, TokenFingerprint(token), records[3]["token_fingerprint"])
+	})
+
+	t.Run("로그에 토큰 원문이 포함되지 않음", func(t *testing.T) {
+		output := buf.String()
+		for _, tokenString := range []string{token, forged} {
+			for _, segment := range strings.Split(tokenString, ".") {
+				assert.NotContains(t, output, segment)
+			}
+		}
+	})
+}
+
+func TestKeyRotationAuditLog(t *testing.T) {
+	newJWKS := func(kid string) *JWKS {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		jwk, err := NewJWK(pub, kid, "EdDSA")
+		require.NoError(t, err)
+		return &JWKS{Keys: []JWK{jwk}}
+	}
+
+	var current atomic.Pointer[JWKS]
+	current.Store(newJWKS("key-1"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(current.Load())
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	keys := NewRemoteKeySet(server.URL, WithRefreshInterval(0), WithKeySetAuditLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+
+	_, err := keys.Key(context.Background(), "key-1")
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+
+	current.Store(newJWKS("key-2"))
+	_, err = keys.Key(context.Background(), "key-2")
+	require.NoError(t, err)
+
+	records := auditRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, AuditKeyRotated, records[0]["msg"])
+	assert.Equal(t, []interface{}{"key-2"}, records[0]["added"])
+	assert.Equal(t, []interface{}{"key-1"}, records[0]["removed"])
+}
@@ -0,0 +1,160 @@
+package v4jwt
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+// tokenClaims: token.Claims 를 golang-jwt v4 의 jwt.Claims 로 사용하기 위한 어댑터
+// 직렬화는 감싼 클레임을 그대로 사용하고, exp, nbf, iat 검증은 jwt.RegisteredClaims 와 동일
+type tokenClaims struct {
+	claims token.Claims
+}
+
+func (c *tokenClaims) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.claims)
+}
+
+func (c *tokenClaims) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, c.claims)
+}
+
+func (c *tokenClaims) Valid() error {
+	registered := c.claims.GetRegisteredClaims()
+	claims := jwt.RegisteredClaims{
+		Issuer:    registered.Issuer,
+		Subject:   registered.Subject,
+		Audience:  jwt.ClaimStrings(registered.Audience),
+		ExpiresAt: numericDate(registered.ExpiresAt),
+		NotBefore: numericDate(registered.NotBefore),
+		IssuedAt:  numericDate(registered.IssuedAt),
+		ID:        registered.ID,
+	}
+	return claims.Valid()
+}
+
+func numericDate(d *token.NumericDate) *jwt.NumericDate {
+	if d == nil {
+		return nil
+	}
+	return jwt.NewNumericDate(d.Time)
+}
+
+// AdaptCreator: Creator 를 token.Claims 로 토큰을 생성하는 token.Creator 로 변환
+func AdaptCreator(creator TokenCreator) token.Creator {
+	return &tokenCreator{creator: creator}
+}
+
+type tokenCreator struct {
+	creator TokenCreator
+}
+
+func (c *tokenCreator) CreateToken(claims token.Claims) (string, error) {
+	if claims == nil {
+		claims = &token.RegisteredClaims{}
+	}
+	return c.creator.CreateToken(&tokenClaims{claims: claims})
+}
+
+// AdaptValidator: Validator[jwt.Claims] 를 token.Validator 로 변환
+// 검증 에러는 token 패키지의 에러와 함께 감싸서 반환
+func AdaptValidator[T token.Claims](validator TokenValidator[jwt.Claims]) token.Validator[T] {
+	return &tokenValidator[T]{validator: validator}
+}
+
+type tokenValidator[T token.Claims] struct {
+	validator TokenValidator[jwt.Claims]
+}
+
+func (v *tokenValidator[T]) ValidateToken(tokenString string, claims T) (T, error) {
+	var empty T
+	if _, err := v.validator.ValidateToken(tokenString, &tokenClaims{claims: claims}); err != nil {
+		return empty, wrapTokenError(err)
+	}
+	return claims, nil
+}
+
+// NewTokenMiddleware: token.Validator 로 검증하는 JwtMiddleware
+// 컨텍스트에는 claims 와 같은 타입의 token.Claims 가 저장되어 token.ClaimsFromContext 로 조회
+func NewTokenMiddleware(extractor Extractor, validator token.Validator[token.Claims], errorHandler ErrorHandler, claims token.Claims, opts ...MiddlewareOption) *JwtMiddleware {
+	return NewJwtMiddleware(extractor, &jwtValidator{validator: validator}, errorHandler, &tokenClaims{claims: claims}, opts...)
+}
+
+// jwtValidator: token.Validator 를 JwtMiddleware 의 TokenValidator[jwt.Claims] 로 사용하기 위한 어댑터
+type jwtValidator struct {
+	validator token.Validator[token.Claims]
+}
+
+func (v *jwtValidator) ValidateToken(tokenString string, claims jwt.Claims) (jwt.Claims, error) {
+	c, ok := claims.(*tokenClaims)
+	if !ok {
+		return nil, ErrTokenInvalidClaims
+	}
+	validated, err := v.validator.ValidateToken(tokenString, c.claims)
+	if err != nil {
+		return nil, wrapJwtError(err)
+	}
+	return &tokenClaims{claims: validated}, nil
+}
+
+// tokenErrors: v4jwt 에러와 대응하는 token 패키지 에러
+var tokenErrors = []struct {
+	jwt   error
+	token error
+}{
+	{ErrTokenMalformed, token.ErrTokenMalformed},
+	{ErrTokenSignatureInvalid, token.ErrTokenSignatureInvalid},
+	{ErrTokenExpired, token.ErrTokenExpired},
+	{ErrTokenNotValidYet, token.ErrTokenNotValidYet},
+	{ErrTokenUsedBeforeIssued, token.ErrTokenUsedBeforeIssued},
+	{ErrTokenInvalidIssuer, token.ErrTokenInvalidIssuer},
+	{ErrTokenInvalidAudience, token.ErrTokenInvalidAudience},
+	{ErrKeyNotFound, token.ErrKeyNotFound},
+	{ErrTokenUnverifiable, token.ErrTokenUnverifiable},
+	{ErrTokenInvalidClaims, token.ErrTokenInvalidClaims},
+}
+
+// tokenError: 메시지는 원래 에러를 그대로 사용하고,
+// errors.Is 로 v4jwt 와 token 패키지의 에러를 모두 확인할 수 있도록 함
+type tokenError struct {
+	err   error
+	kinds []error
+}
+
+func (e *tokenError) Error() string {
+	return e.err.Error()
+}
+
+func (e *tokenError) Unwrap() []error {
+	return append([]error{e.err}, e.kinds...)
+}
+
+func wrapTokenError(err error) error {
+	var kinds []error
+	for _, e := range tokenErrors {
+		if errors.Is(err, e.jwt) {
+			kinds = append(kinds, e.token)
+		}
+	}
+	if len(kinds) == 0 {
+		return err
+	}
+	return &tokenError{err: err, kinds: kinds}
+}
+
+// wrapJwtError: wrapTokenError 의 반대 방향, DefaultErrorHandler 와 ErrorClass 가 token 패키지의 에러도 구분하도록 함
+func wrapJwtError(err error) error {
+	var kinds []error
+	for _, e := range tokenErrors {
+		if errors.Is(err, e.token) && !errors.Is(err, e.jwt) {
+			kinds = append(kinds, e.jwt)
+		}
+	}
+	if len(kinds) == 0 {
+		return err
+	}
+	return &tokenError{err: err, kinds: kinds}
+}
@@ -0,0 +1,94 @@
+package v4jwt
+
+import (
+	"crypto"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+	"github.com/nookcoder/go-boilerplate/auth/token/tokentest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConformance(t *testing.T) {
+	fixtures := tokentest.JWTFixtures(t, func(alg string, signingKey crypto.PrivateKey, verifyKey crypto.PublicKey) *Config {
+		if secret, ok := signingKey.([]byte); ok {
+			return NewConfig(jwt.GetSigningMethod(alg), secret)
+		}
+		return NewKeyPairConfig(jwt.GetSigningMethod(alg), signingKey, verifyKey)
+	})
+
+	tokentest.RunFixtures(t, fixtures, func(f tokentest.Fixture[*Config]) tokentest.Backend {
+		return tokentest.Backend{
+			NewCreator: func(typ string) token.Creator {
+				return AdaptCreator(NewCreator(f.Config, WithTokenType(typ)))
+			},
+			OtherKeyCreator: AdaptCreator(NewCreator(f.OtherKey)),
+			OtherAlgCreator: AdaptCreator(NewCreator(f.OtherAlg)),
+			NewValidator: func(o tokentest.ValidatorOptions) token.Validator[token.Claims] {
+				opts := []ValidatorOption{WithIssuer(o.Issuer), WithAudience(o.Audience)}
+				if len(o.ExpectedTypes) > 0 {
+					opts = append(opts, WithExpectedTypes(o.ExpectedTypes...))
+				}
+				return AdaptValidator[token.Claims](NewValidator[jwt.Claims](f.Config, opts...))
+			},
+			NewMiddleware: func(validator token.Validator[token.Claims], claims token.Claims) func(http.Handler) http.Handler {
+				return NewTokenMiddleware(AuthHeaderExtractor, validator, nil, claims).CheckJwt
+			},
+		}
+	})
+}
+
+// expiredValidator: 항상 token 패키지의 만료 에러를 반환하는 검증기
+type expiredValidator struct{}
+
+func (expiredValidator) ValidateToken(string, token.Claims) (token.Claims, error) {
+	return nil, token.ErrTokenExpired
+}
+
+func TestNewTokenMiddleware(t *testing.T) {
+	handler := NewTokenMiddleware(AuthHeaderExtractor, expiredValidator{}, nil, &token.RegisteredClaims{}).CheckJwt(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	)
+
+	testCases := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "token 패키지의 에러도 DefaultErrorHandler 가 구분",
+			authorization:  "Bearer token",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "token is expired or not valid yet",
+		},
+		{
+			name:           "토큰 없음",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "missing token",
+		},
+		{
+			name:           "Bearer 형식이 아님",
+			authorization:  "token",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "invalid authorization header",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.expectedBody)
+		})
+	}
+}
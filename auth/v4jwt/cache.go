@@ -0,0 +1,175 @@
+package v4jwt
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// CacheStats: 검증 캐시 통계
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Size   int
+}
+
+// WithTokenCache: 서명 검증에 성공한 토큰을 exp 까지 최대 size 개 캐시 (LRU)
+// 캐시된 토큰은 서명 검증과 클레임의 Valid 를 생략하고 클레임만 디코딩
+// exp 가 없는 토큰은 캐시하지 않으며, WithReplayCache 와 함께 사용하면 캐시하지 않음
+func WithTokenCache(size int) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.tokenCache = newTokenCache(size)
+	}
+}
+
+// Invalidate: jti 로 캐시된 토큰 삭제, 토큰 폐기 이벤트 수신 시 호출
+func (v *Validator[T]) Invalidate(jti string) {
+	if v.Config.auditLogger != nil {
+		auditTokenRevoked(v.Config.auditLogger, slog.String("jti", jti))
+	}
+	if v.tokenCache != nil {
+		v.tokenCache.removeID(jti)
+	}
+}
+
+// InvalidateToken: 토큰 문자열로 캐시된 토큰 삭제
+func (v *Validator[T]) InvalidateToken(tokenString string) {
+	if v.Config.auditLogger != nil {
+		auditTokenRevoked(v.Config.auditLogger, slog.String("token_fingerprint", TokenFingerprint(tokenString)))
+	}
+	if v.tokenCache != nil {
+		v.tokenCache.remove(sha256.Sum256([]byte(tokenString)))
+	}
+}
+
+// CacheStats: 캐시를 사용하지 않으면 빈 값 반환
+func (v *Validator[T]) CacheStats() CacheStats {
+	if v.tokenCache == nil {
+		return CacheStats{}
+	}
+	return v.tokenCache.stats()
+}
+
+type tokenCacheEntry struct {
+	key       [32]byte
+	id        string
+	expiresAt time.Time
+}
+
+// tokenCache: 토큰 해시를 키로 하는 LRU 캐시
+// 클레임은 요청마다 새로 디코딩하므로 검증 여부와 만료 시각만 저장
+type tokenCache struct {
+	size int
+	// now: 만료 확인에 사용하는 현재 시각, 기본값은 클레임 검증과 같은 jwt.TimeFunc
+	now func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[[32]byte]*list.Element
+	ids     map[string][32]byte
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func newTokenCache(size int) *tokenCache {
+	return &tokenCache{
+		size:    size,
+		now:     func() time.Time { return jwt.TimeFunc() },
+		order:   list.New(),
+		entries: make(map[[32]byte]*list.Element),
+		ids:     make(map[string][32]byte),
+	}
+}
+
+// get: 만료되지 않은 토큰이면 true
+func (c *tokenCache) get(key [32]byte) bool {
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.misses.Add(1)
+		return false
+	}
+
+	entry := el.Value.(*tokenCacheEntry)
+	if !now.Before(entry.expiresAt) {
+		c.removeElement(el)
+		c.misses.Add(1)
+		return false
+	}
+
+	c.order.MoveToFront(el)
+	c.hits.Add(1)
+	return true
+}
+
+func (c *tokenCache) add(key [32]byte, claims *jwt.RegisteredClaims) {
+	if c.size <= 0 || claims.ExpiresAt == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return
+	}
+
+	entry := &tokenCacheEntry{key: key, id: claims.ID, expiresAt: claims.ExpiresAt.Time}
+	c.entries[key] = c.order.PushFront(entry)
+	if entry.id != "" {
+		c.ids[entry.id] = key
+	}
+
+	for c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *tokenCache) remove(key [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+func (c *tokenCache) removeID(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.ids[id]; ok {
+		c.removeElement(c.entries[key])
+	}
+}
+
+func (c *tokenCache) removeElement(el *list.Element) {
+	entry := c.order.Remove(el).(*tokenCacheEntry)
+	delete(c.entries, entry.key)
+	if entry.id != "" && c.ids[entry.id] == entry.key {
+		delete(c.ids, entry.id)
+	}
+}
+
+func (c *tokenCache) stats() CacheStats {
+	c.mu.Lock()
+	size := c.order.Len()
+	c.mu.Unlock()
+
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Size:   size,
+	}
+}
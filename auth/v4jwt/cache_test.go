@@ -0,0 +1,132 @@
+package v4jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorTokenCache(t *testing.T) {
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"))
+	creator := NewCreator(config)
+
+	newToken := func(jti string, exp time.Time) string {
+		token, err := creator.CreateToken(&validateTestClaims{
+			UserId: "123",
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        jti,
+				ExpiresAt: jwt.NewNumericDate(exp),
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("두 번째 검증부터 캐시 사용", func(t *testing.T) {
+		validator := NewValidator[*validateTestClaims](config, WithTokenCache(10))
+		token := newToken("at-1", time.Now().Add(time.Minute))
+
+		first, err := validator.ValidateToken(token, &validateTestClaims{})
+		require.NoError(t, err)
+		second, err := validator.ValidateToken(token, &validateTestClaims{})
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		// 요청마다 다른 인스턴스에 디코딩
+		assert.NotSame(t, first, second)
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 1, Size: 1}, validator.CacheStats())
+	})
+
+	t.Run("만료 시각이 지나면 캐시에서 제거", func(t *testing.T) {
+		validator := NewValidator[*validateTestClaims](config, WithTokenCache(10))
+		token := newToken("at-1", time.Now().Add(time.Minute))
+		_, err := validator.ValidateToken(token, &validateTestClaims{})
+		require.NoError(t, err)
+
+		// 캐시의 시각만 exp 이후로 옮겨 캐시를 건너뛰고 다시 검증하는지 확인
+		validator.tokenCache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+		_, err = validator.ValidateToken(token, &validateTestClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, CacheStats{Hits: 0, Misses: 2, Size: 1}, validator.CacheStats())
+	})
+
+	t.Run("폐기 이벤트로 무효화", func(t *testing.T) {
+		validator := NewValidator[*validateTestClaims](config, WithTokenCache(10))
+		first := newToken("at-1", time.Now().Add(time.Minute))
+		second := newToken("at-2", time.Now().Add(time.Minute))
+		for _, token := range []string{first, second} {
+			_, err := validator.ValidateToken(token, &validateTestClaims{})
+			require.NoError(t, err)
+		}
+
+		validator.Invalidate("at-1")
+		validator.InvalidateToken(second)
+		assert.Equal(t, 0, validator.CacheStats().Size)
+	})
+
+	t.Run("최대 크기를 넘으면 가장 오래 사용하지 않은 토큰 제거", func(t *testing.T) {
+		validator := NewValidator[*validateTestClaims](config, WithTokenCache(2))
+		tokens := make([]string, 3)
+		for i := range tokens {
+			tokens[i] = newToken(fmt.Sprintf("at-%d", i), time.Now().Add(time.Minute))
+		}
+
+		for _, token := range []string{tokens[0], tokens[1], tokens[0], tokens[2]} {
+			_, err := validator.ValidateToken(token, &validateTestClaims{})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 3, Size: 2}, validator.CacheStats())
+
+		// tokens[1] 이 제거됨
+		_, err := validator.ValidateToken(tokens[0], &validateTestClaims{})
+		require.NoError(t, err)
+		_, err = validator.ValidateToken(tokens[1], &validateTestClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, CacheStats{Hits: 2, Misses: 4, Size: 2}, validator.CacheStats())
+	})
+
+	t.Run("서명이 잘못된 토큰은 캐시하지 않음", func(t *testing.T) {
+		validator := NewValidator[*validateTestClaims](NewConfig(jwt.SigningMethodHS256, []byte("other")), WithTokenCache(10))
+		token := newToken("at-1", time.Now().Add(time.Minute))
+		for i := 0; i < 2; i++ {
+			_, err := validator.ValidateToken(token, &validateTestClaims{})
+			assert.ErrorIs(t, err, ErrTokenSignatureInvalid)
+		}
+		assert.Equal(t, 0, validator.CacheStats().Size)
+	})
+}
+
+func benchmarkValidateToken(b *testing.B, opts ...ValidatorOption) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(b, err)
+	config := NewKeyPairConfig(jwt.SigningMethodRS256, key, &key.PublicKey)
+	token, err := NewCreator(config).CreateToken(&jwt.RegisteredClaims{
+		Subject:   "user-1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	require.NoError(b, err)
+
+	validator := NewValidator[*jwt.RegisteredClaims](config, opts...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := validator.ValidateToken(token, &jwt.RegisteredClaims{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateToken(b *testing.B) {
+	b.Run("uncached", func(b *testing.B) {
+		benchmarkValidateToken(b)
+	})
+	b.Run("cached", func(b *testing.B) {
+		benchmarkValidateToken(b, WithTokenCache(1024))
+	})
+}
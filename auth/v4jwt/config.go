@@ -1,15 +1,114 @@
 package v4jwt
 
-import "github.com/golang-jwt/jwt/v4"
+import (
+	"crypto"
+	"log/slog"
+
+	"github.com/golang-jwt/jwt/v4"
+)
 
 type Config struct {
-	method jwt.SigningMethod
-	secretKey []byte 
+	method    jwt.SigningMethod
+	secretKey []byte
+	// RSA, ECDSA, EdDSA 처럼 서명키와 검증키가 다른 경우 사용
+	signingKey crypto.PrivateKey
+	verifyKey  crypto.PublicKey
+	keyID      string
+	metrics    Metrics
+	// auditLogger: nil 이면 감사 로그를 남기지 않음
+	auditLogger *slog.Logger
+	tracer      Tracer
+	// keyRing: 설정되어 있으면 secretKey, keyID 대신 키 링의 현재 secret 과 버전을 사용
+	keyRing *SecretKeyRing
+}
+
+type ConfigOption func(*Config)
+
+// WithKeyID: 토큰 헤더의 kid 값 설정
+func WithKeyID(keyID string) ConfigOption {
+	return func(c *Config) {
+		c.keyID = keyID
+	}
 }
 
-func NewConfig(method jwt.SigningMethod, secretKey []byte) *Config { 
-	return &Config{ 
-		method: method,
+func NewConfig(method jwt.SigningMethod, secretKey []byte, opts ...ConfigOption) *Config {
+	c := &Config{
+		method:    method,
 		secretKey: secretKey,
 	}
-}
\ No newline at end of file
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewKeyPairConfig: 비대칭 키 설정
+// 검증만 하는 경우 signingKey 는 nil 로 설정
+func NewKeyPairConfig(method jwt.SigningMethod, signingKey crypto.PrivateKey, verifyKey crypto.PublicKey, opts ...ConfigOption) *Config {
+	c := &Config{
+		method:     method,
+		signingKey: signingKey,
+		verifyKey:  verifyKey,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Config) Method() jwt.SigningMethod {
+	return c.method
+}
+
+func (c *Config) KeyID() string {
+	if c.keyRing != nil {
+		return c.keyRing.KeyID()
+	}
+	return c.keyID
+}
+
+// SigningKey: 토큰 서명에 사용하는 키
+func (c *Config) SigningKey() interface{} {
+	if c.signingKey != nil {
+		return c.signingKey
+	}
+	if c.keyRing != nil {
+		key, _, _ := c.keyRing.SigningKey()
+		return key
+	}
+	return c.secretKey
+}
+
+// VerifyKey: 토큰 검증에 사용하는 키
+func (c *Config) VerifyKey() interface{} {
+	if c.verifyKey != nil {
+		return c.verifyKey
+	}
+	if c.keyRing != nil {
+		key, _ := c.keyRing.VerifyKey("")
+		return key
+	}
+	return c.secretKey
+}
+
+// signingKeyWithID: 서명키와 헤더의 kid 를 함께 조회
+// 키 링이 교체되는 중에도 서명키와 kid 가 어긋나지 않도록 함
+func (c *Config) signingKeyWithID() (interface{}, string, error) {
+	if c.keyRing != nil {
+		key, kid, err := c.keyRing.SigningKey()
+		if err != nil {
+			return nil, "", err
+		}
+		return key, kid, nil
+	}
+	return c.SigningKey(), c.keyID, nil
+}
+
+// verifyKeyFor: 토큰 헤더의 kid 에 맞는 검증키, 키 링이 없으면 VerifyKey
+func (c *Config) verifyKeyFor(token *jwt.Token) (interface{}, error) {
+	if c.keyRing != nil {
+		kid, _ := token.Header["kid"].(string)
+		return c.keyRing.VerifyKey(kid)
+	}
+	return c.VerifyKey(), nil
+}
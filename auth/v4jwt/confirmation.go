@@ -0,0 +1,87 @@
+package v4jwt
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Confirmation: 토큰을 특정 키에 바인딩하는 cnf 클레임 (RFC 7800)
+type Confirmation struct {
+	// JKT: DPoP 공개키의 JWK Thumbprint (RFC 9449 Section 6.1)
+	JKT string `json:"jkt,omitempty"`
+	// X5TS256: 클라이언트 인증서의 SHA-256 Thumbprint (RFC 8705 Section 3.1)
+	X5TS256 string `json:"x5t#S256,omitempty"`
+}
+
+// CertificateThumbprint: DER 인코딩 인증서의 SHA-256 해시 (base64url)
+func CertificateThumbprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// BoundClaims: cnf 클레임을 가진 클레임 타입
+type BoundClaims interface {
+	jwt.Claims
+	GetConfirmation() *Confirmation
+	SetConfirmation(cnf *Confirmation)
+}
+
+// ConfirmationClaims: 커스텀 클레임에 임베딩하여 BoundClaims 를 구현
+//
+//	type AppClaims struct {
+//		UserId string `json:"user_id"`
+//		v4jwt.ConfirmationClaims
+//		jwt.RegisteredClaims
+//	}
+type ConfirmationClaims struct {
+	Cnf *Confirmation `json:"cnf,omitempty"`
+}
+
+func (c *ConfirmationClaims) GetConfirmation() *Confirmation {
+	return c.Cnf
+}
+
+func (c *ConfirmationClaims) SetConfirmation(cnf *Confirmation) {
+	c.Cnf = cnf
+}
+
+// CreateBoundToken: cnf 클레임을 설정한 뒤 토큰 생성
+func (c *Creator) CreateBoundToken(claims BoundClaims, cnf *Confirmation) (string, error) {
+	claims.SetConfirmation(cnf)
+	return c.CreateToken(claims)
+}
+
+// CreateCertificateBoundToken: 클라이언트 인증서에 바인딩된 토큰 생성
+// 토큰 발급 요청의 r.TLS.PeerCertificates[0] 를 전달
+func (c *Creator) CreateCertificateBoundToken(claims BoundClaims, cert *x509.Certificate) (string, error) {
+	cnf := claims.GetConfirmation()
+	if cnf == nil {
+		cnf = &Confirmation{}
+	}
+	cnf.X5TS256 = CertificateThumbprint(cert)
+	return c.CreateBoundToken(claims, cnf)
+}
+
+// tokenConfirmation: 서명 검증이 끝난 토큰의 페이로드에서 cnf 조회, cnf 가 없으면 nil
+// 미들웨어의 클레임 타입 (RegisteredClaims, MapClaims 등) 이 cnf 를 디코딩하지 않아도 바인딩을 확인
+func tokenConfirmation(tokenString string) (*Confirmation, error) {
+	parts := strings.Split(tokenString, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenMalformed
+	}
+	payload, err := jwt.DecodeSegment(parts[1])
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	var claims ConfirmationClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrTokenMalformed
+	}
+	return claims.Cnf, nil
+}
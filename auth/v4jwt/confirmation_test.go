@@ -0,0 +1,155 @@
+package v4jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClientCertificate: 테스트용 self-signed 클라이언트 인증서 생성
+func newClientCertificate(t *testing.T, commonName string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestCertificateBoundToken(t *testing.T) {
+	clientCert := newClientCertificate(t, "client-1")
+	otherCert := newClientCertificate(t, "client-2")
+
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"))
+	creator := NewCreator(config)
+	registered := jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}
+
+	boundToken, err := creator.CreateCertificateBoundToken(&dpopTestClaims{RegisteredClaims: registered}, clientCert.Leaf)
+	require.NoError(t, err)
+	unboundToken, err := creator.CreateToken(&dpopTestClaims{RegisteredClaims: registered})
+	require.NoError(t, err)
+
+	claims, err := NewValidator[*dpopTestClaims](config).ValidateToken(boundToken, &dpopTestClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, CertificateThumbprint(clientCert.Leaf), claims.Cnf.X5TS256)
+
+	newServer := func(opts ...MiddlewareOption) *httptest.Server {
+		m := NewJwtMiddleware(AuthHeaderExtractor, NewValidator[jwt.Claims](config), nil, &dpopTestClaims{}, opts...)
+		server := httptest.NewUnstartedServer(m.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+		server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+		server.StartTLS()
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	request := func(server *httptest.Server, cert *tls.Certificate, token string) *http.Response {
+		client := server.Client()
+		transport := client.Transport.(*http.Transport).Clone()
+		if cert != nil {
+			transport.TLSClientConfig.Certificates = []tls.Certificate{*cert}
+		}
+		client = &http.Client{Transport: transport}
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	testCases := []struct {
+		name           string
+		cert           *tls.Certificate
+		token          string
+		required       bool
+		expectedStatus int
+	}{
+		{name: "바인딩 된 인증서로 요청", cert: &clientCert, token: boundToken, expectedStatus: http.StatusOK},
+		{name: "다른 인증서로 요청", cert: &otherCert, token: boundToken, expectedStatus: http.StatusUnauthorized},
+		{name: "인증서 없이 요청", token: boundToken, expectedStatus: http.StatusUnauthorized},
+		{name: "바인딩 되지 않은 토큰", cert: &otherCert, token: unboundToken, expectedStatus: http.StatusOK},
+		{name: "바인딩 필수인 경우 바인딩 되지 않은 토큰 거부", cert: &clientCert, token: unboundToken, required: true, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			opt := WithCertificateBinding()
+			if tc.required {
+				opt = WithCertificateBindingRequired()
+			}
+			resp := request(newServer(opt), tc.cert, tc.token)
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+		})
+	}
+
+	t.Run("불일치는 별도의 에러로 전달", func(t *testing.T) {
+		var got error
+		m := NewJwtMiddleware(AuthHeaderExtractor, NewValidator[jwt.Claims](config), func(w http.ResponseWriter, r *http.Request, err error) {
+			got = err
+		}, &dpopTestClaims{}, WithCertificateBinding())
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+boundToken)
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{otherCert.Leaf}}
+		m.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(httptest.NewRecorder(), r)
+		assert.ErrorIs(t, got, ErrCertificateBindingMismatch)
+	})
+
+	t.Run("기본 클레임 타입도 cnf.x5t#S256 확인", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			prototype jwt.Claims
+			cert      *x509.Certificate
+		}{
+			{name: "RegisteredClaims, 다른 인증서", prototype: &jwt.RegisteredClaims{}, cert: otherCert.Leaf},
+			{name: "RegisteredClaims, 인증서 없음", prototype: &jwt.RegisteredClaims{}},
+			{name: "MapClaims, 다른 인증서", prototype: jwt.MapClaims{}, cert: otherCert.Leaf},
+			{name: "nil, 인증서 없음"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var got error
+				m := NewJwtMiddleware(AuthHeaderExtractor, NewValidator[jwt.Claims](config), func(w http.ResponseWriter, r *http.Request, err error) {
+					got = err
+				}, tc.prototype, WithCertificateBinding())
+
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				r.Header.Set("Authorization", "Bearer "+boundToken)
+				if tc.cert != nil {
+					r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{tc.cert}}
+				}
+				m.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(httptest.NewRecorder(), r)
+				assert.ErrorIs(t, got, ErrCertificateBindingMismatch)
+			})
+		}
+	})
+}
@@ -1,23 +1,84 @@
 package v4jwt
 
-import "github.com/golang-jwt/jwt/v4"
+import (
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/golang-jwt/jwt/v4"
+)
 
 type Creator struct {
-	*Config 
+	*Config
+	tokenType string
+}
+
+type CreatorOption func(*Creator)
+
+// WithTokenType: 발급하는 토큰 헤더의 typ (예: AccessTokenType)
+func WithTokenType(typ string) CreatorOption {
+	return func(c *Creator) {
+		c.tokenType = typ
+	}
 }
 
-func NewCreator(config *Config) *Creator { 
-	return &Creator{ 
+func NewCreator(config *Config, opts ...CreatorOption) *Creator {
+	c := &Creator{
 		Config: config,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-func (c *Creator) CreateToken(claims jwt.Claims) (string, error) { 
-	if claims != nil { 
-		t := jwt.NewWithClaims(c.Config.method, claims) 
-		return t.SignedString(c.Config.secretKey)
+func (c *Creator) CreateToken(claims jwt.Claims) (string, error) {
+	return c.createToken(claims, c.tokenType)
+}
+
+// CreateTypedToken: 헤더의 typ 을 지정하여 토큰 생성
+// 같은 키로 access, refresh 토큰 등을 함께 발급하는 경우 사용
+func (c *Creator) CreateTypedToken(claims jwt.Claims, typ string) (string, error) {
+	return c.createToken(claims, typ)
+}
+
+// createToken: typ 이 비어있지 않으면 헤더의 typ 을 설정
+func (c *Creator) createToken(claims jwt.Claims, typ string) (string, error) {
+	var t *jwt.Token
+	if claims != nil {
+		t = jwt.NewWithClaims(c.Config.method, claims)
+	} else {
+		t = jwt.New(c.Config.method)
 	}
 
-	t := jwt.New(c.Config.method) 
-	return t.SignedString(c.Config.secretKey)
-}
\ No newline at end of file
+	key, kid, err := c.Config.signingKeyWithID()
+	if err != nil {
+		return "", err
+	}
+	if kid != "" {
+		t.Header["kid"] = kid
+	}
+	if typ != "" {
+		t.Header["typ"] = typ
+	}
+
+	token, err := t.SignedString(key)
+	if err != nil {
+		return "", err
+	}
+	if c.Config.metrics != nil {
+		c.Config.metrics.TokenIssued(c.Config.method.Alg())
+	}
+	if c.Config.auditLogger != nil {
+		auditTokenIssued(c.Config.auditLogger, token, kid)
+	}
+	return token, nil
+}
+
+// NewTokenID: jti 클레임으로 사용할 랜덤 ID 생성
+func NewTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,245 @@
+package v4jwt
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	DPoPHeader    = "DPoP"
+	DPoPProofType = "dpop+jwt"
+
+	defaultDPoPMaxAge = 5 * time.Minute
+	defaultDPoPLeeway = 30 * time.Second
+)
+
+var (
+	ErrDPoPProofMissing    = errors.New("missing dpop proof")
+	ErrDPoPProofInvalid    = errors.New("invalid dpop proof")
+	ErrDPoPProofReplayed   = errors.New("dpop proof has already been used")
+	ErrDPoPBindingMismatch = errors.New("access token is not bound to the dpop key")
+)
+
+// DPoP proof 서명에 허용하는 비대칭 알고리즘 (RFC 9449 Section 4.2)
+var dpopAlgs = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// DPoPProofClaims: DPoP proof JWT 클레임
+type DPoPProofClaims struct {
+	HTM string `json:"htm"`
+	HTU string `json:"htu"`
+	// ATH: access 토큰의 SHA-256 해시 (base64url)
+	ATH string `json:"ath,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (c *DPoPProofClaims) Valid() error {
+	if c.ID == "" || c.IssuedAt == nil || c.HTM == "" || c.HTU == "" {
+		return fmt.Errorf("%w: jti, iat, htm and htu are required", ErrDPoPProofInvalid)
+	}
+	return nil
+}
+
+// DPoPValidator: DPoP 헤더의 proof 검증 (RFC 9449 Section 4.3)
+type DPoPValidator struct {
+	maxAge     time.Duration
+	leeway     time.Duration
+	requestURL func(r *http.Request) string
+	now        func() time.Time
+	replay     ReplayCache
+}
+
+type DPoPOption func(*DPoPValidator)
+
+// WithDPoPMaxAge: proof 의 iat 로부터 허용하는 최대 시간
+func WithDPoPMaxAge(maxAge time.Duration) DPoPOption {
+	return func(v *DPoPValidator) {
+		v.maxAge = maxAge
+	}
+}
+
+// WithDPoPReplayCache: proof 의 jti 를 기록할 ReplayCache
+// 여러 인스턴스가 같은 토큰을 검증하는 경우 공유 저장소 구현체 사용
+func WithDPoPReplayCache(cache ReplayCache) DPoPOption {
+	return func(v *DPoPValidator) {
+		v.replay = cache
+	}
+}
+
+// WithDPoPRequestURL: htu 와 비교할 요청 URL 계산 함수
+// 리버스 프록시 뒤에서 외부 URL 이 다른 경우 사용
+func WithDPoPRequestURL(fn func(r *http.Request) string) DPoPOption {
+	return func(v *DPoPValidator) {
+		v.requestURL = fn
+	}
+}
+
+func NewDPoPValidator(opts ...DPoPOption) *DPoPValidator {
+	v := &DPoPValidator{
+		maxAge:     defaultDPoPMaxAge,
+		leeway:     defaultDPoPLeeway,
+		requestURL: defaultRequestURL,
+		now:        time.Now,
+		replay:     NewMemoryReplayCache(),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// ValidateProof: 요청의 DPoP proof 를 검증하고 proof 공개키의 JWK Thumbprint 반환
+// accessToken 이 비어있지 않으면 ath 클레임도 확인
+func (v *DPoPValidator) ValidateProof(r *http.Request, accessToken string) (string, error) {
+	proofs := r.Header.Values(DPoPHeader)
+	if len(proofs) == 0 {
+		return "", ErrDPoPProofMissing
+	}
+	if len(proofs) > 1 {
+		return "", fmt.Errorf("%w: multiple proofs", ErrDPoPProofInvalid)
+	}
+
+	var jwk JWK
+	claims := &DPoPProofClaims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(dpopAlgs))
+	token, err := parser.ParseWithClaims(proofs[0], claims, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != DPoPProofType {
+			return nil, fmt.Errorf("%w: typ must be %s", ErrDPoPProofInvalid, DPoPProofType)
+		}
+
+		var err error
+		jwk, err = proofKey(token.Header["jwk"])
+		if err != nil {
+			return nil, err
+		}
+		return jwk.PublicKey()
+	})
+	if err != nil {
+		if errors.Is(err, ErrDPoPProofInvalid) {
+			return "", err
+		}
+		return "", fmt.Errorf("%w: %v", ErrDPoPProofInvalid, err)
+	}
+	if !token.Valid {
+		return "", ErrDPoPProofInvalid
+	}
+
+	if claims.HTM != r.Method {
+		return "", fmt.Errorf("%w: htm does not match", ErrDPoPProofInvalid)
+	}
+	if !sameURL(claims.HTU, v.requestURL(r)) {
+		return "", fmt.Errorf("%w: htu does not match", ErrDPoPProofInvalid)
+	}
+
+	now := v.now()
+	iat := claims.IssuedAt.Time
+	if iat.After(now.Add(v.leeway)) || iat.Before(now.Add(-v.maxAge)) {
+		return "", fmt.Errorf("%w: iat is outside the acceptable window", ErrDPoPProofInvalid)
+	}
+
+	if accessToken != "" {
+		if subtle.ConstantTimeCompare([]byte(claims.ATH), []byte(AccessTokenHash(accessToken))) != 1 {
+			return "", fmt.Errorf("%w: ath does not match", ErrDPoPProofInvalid)
+		}
+	}
+
+	// jti 는 proof 가 유효한 기간 동안만 기억
+	ok, err := v.replay.Use(r.Context(), claims.ID, iat.Add(v.maxAge+v.leeway))
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", ErrDPoPProofReplayed
+	}
+
+	return jwk.Thumbprint()
+}
+
+// AccessTokenHash: DPoP proof 의 ath 값, BASE64URL(SHA256(access_token))
+func AccessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// CreateDPoPProof: 요청에 첨부할 DPoP proof 생성 (클라이언트용)
+// accessToken 이 비어있으면 ath 를 포함하지 않음 (토큰 발급 요청 등)
+func CreateDPoPProof(method jwt.SigningMethod, key crypto.Signer, htm, htu, accessToken string) (string, error) {
+	jwk, err := NewJWK(key.Public(), "", "")
+	if err != nil {
+		return "", err
+	}
+	jwk.Use = ""
+
+	jti, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	claims := &DPoPProofClaims{
+		HTM: htm,
+		HTU: htu,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       jti,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}
+	if accessToken != "" {
+		claims.ATH = AccessTokenHash(accessToken)
+	}
+
+	t := jwt.NewWithClaims(method, claims)
+	t.Header["typ"] = DPoPProofType
+	t.Header["jwk"] = jwk
+	return t.SignedString(key)
+}
+
+// proofKey: proof 헤더의 jwk 를 공개키 JWK 로 변환, 개인키가 포함된 경우 거부
+func proofKey(header interface{}) (JWK, error) {
+	raw, ok := header.(map[string]interface{})
+	if !ok {
+		return JWK{}, fmt.Errorf("%w: jwk header is required", ErrDPoPProofInvalid)
+	}
+	if _, ok := raw["d"]; ok {
+		return JWK{}, fmt.Errorf("%w: jwk must not contain a private key", ErrDPoPProofInvalid)
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return JWK{}, err
+	}
+	var jwk JWK
+	if err := json.Unmarshal(b, &jwk); err != nil {
+		return JWK{}, fmt.Errorf("%w: %v", ErrDPoPProofInvalid, err)
+	}
+	return jwk, nil
+}
+
+func defaultRequestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.Path
+}
+
+// sameURL: query, fragment 를 제외하고 비교 (RFC 9449 Section 4.3)
+func sameURL(htu, requestURL string) bool {
+	a, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	b, err := url.Parse(requestURL)
+	if err != nil {
+		return false
+	}
+	return a.Scheme == b.Scheme && a.Host == b.Host && a.Path == b.Path
+}
@@ -0,0 +1,216 @@
+package v4jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dpopTestClaims struct {
+	ConfirmationClaims
+	jwt.RegisteredClaims
+}
+
+func TestDPoPValidator(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	const htu = "http://api.example.com/resource"
+	const accessToken = "access-token"
+
+	newRequest := func(proof string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, htu+"?q=1", nil)
+		if proof != "" {
+			r.Header.Set(DPoPHeader, proof)
+		}
+		return r
+	}
+
+	t.Run("유효한 proof 는 공개키 thumbprint 반환", func(t *testing.T) {
+		proof, err := CreateDPoPProof(jwt.SigningMethodES256, key, http.MethodGet, htu, accessToken)
+		require.NoError(t, err)
+
+		jkt, err := NewDPoPValidator().ValidateProof(newRequest(proof), accessToken)
+		require.NoError(t, err)
+
+		jwk, err := NewJWK(&key.PublicKey, "", "")
+		require.NoError(t, err)
+		expected, err := jwk.Thumbprint()
+		require.NoError(t, err)
+		assert.Equal(t, expected, jkt)
+	})
+
+	testCases := []struct {
+		name          string
+		htm           string
+		htu           string
+		accessToken   string
+		expectedError error
+	}{
+		{name: "htm 이 다른 경우", htm: http.MethodPost, htu: htu, accessToken: accessToken, expectedError: ErrDPoPProofInvalid},
+		{name: "htu 가 다른 경우", htm: http.MethodGet, htu: "http://api.example.com/other", accessToken: accessToken, expectedError: ErrDPoPProofInvalid},
+		{name: "ath 가 다른 경우", htm: http.MethodGet, htu: htu, accessToken: "other-token", expectedError: ErrDPoPProofInvalid},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			proof, err := CreateDPoPProof(jwt.SigningMethodES256, key, tc.htm, tc.htu, tc.accessToken)
+			require.NoError(t, err)
+
+			_, err = NewDPoPValidator().ValidateProof(newRequest(proof), accessToken)
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+
+	t.Run("proof 가 없는 경우", func(t *testing.T) {
+		_, err := NewDPoPValidator().ValidateProof(newRequest(""), accessToken)
+		assert.ErrorIs(t, err, ErrDPoPProofMissing)
+	})
+
+	t.Run("같은 proof 를 재사용 하는 경우", func(t *testing.T) {
+		proof, err := CreateDPoPProof(jwt.SigningMethodES256, key, http.MethodGet, htu, accessToken)
+		require.NoError(t, err)
+
+		v := NewDPoPValidator()
+		_, err = v.ValidateProof(newRequest(proof), accessToken)
+		require.NoError(t, err)
+		_, err = v.ValidateProof(newRequest(proof), accessToken)
+		assert.ErrorIs(t, err, ErrDPoPProofReplayed)
+	})
+
+	t.Run("오래된 proof", func(t *testing.T) {
+		proof, err := CreateDPoPProof(jwt.SigningMethodES256, key, http.MethodGet, htu, accessToken)
+		require.NoError(t, err)
+
+		v := NewDPoPValidator()
+		v.now = func() time.Time { return time.Now().Add(time.Hour) }
+		_, err = v.ValidateProof(newRequest(proof), accessToken)
+		assert.ErrorIs(t, err, ErrDPoPProofInvalid)
+	})
+
+	t.Run("대칭키로 서명된 proof", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, &DPoPProofClaims{HTM: http.MethodGet, HTU: htu})
+		token.Header["typ"] = DPoPProofType
+		proof, err := token.SignedString([]byte("secret"))
+		require.NoError(t, err)
+
+		_, err = NewDPoPValidator().ValidateProof(newRequest(proof), accessToken)
+		assert.ErrorIs(t, err, ErrDPoPProofInvalid)
+	})
+}
+
+func TestJwtMiddlewareDPoP(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := NewJWK(&key.PublicKey, "", "")
+	require.NoError(t, err)
+	jkt, err := jwk.Thumbprint()
+	require.NoError(t, err)
+
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"))
+	creator := NewCreator(config)
+
+	registered := jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}
+	boundToken, err := creator.CreateBoundToken(&dpopTestClaims{RegisteredClaims: registered}, &Confirmation{JKT: jkt})
+	require.NoError(t, err)
+	bearerToken, err := creator.CreateToken(&dpopTestClaims{RegisteredClaims: registered})
+	require.NoError(t, err)
+
+	const htu = "http://api.example.com/resource"
+	proof := func(key *ecdsa.PrivateKey, token string) string {
+		p, err := CreateDPoPProof(jwt.SigningMethodES256, key, http.MethodGet, htu, token)
+		require.NoError(t, err)
+		return p
+	}
+
+	testCases := []struct {
+		name           string
+		authorization  string
+		proof          string
+		required       bool
+		expectedStatus int
+	}{
+		{name: "바인딩 된 토큰과 일치하는 proof", authorization: "DPoP " + boundToken, proof: proof(key, boundToken), expectedStatus: http.StatusOK},
+		{name: "바인딩 된 토큰을 Bearer 로 전달", authorization: "Bearer " + boundToken, expectedStatus: http.StatusUnauthorized},
+		{name: "바인딩 된 토큰과 proof 없이 DPoP 로 전달", authorization: "DPoP " + boundToken, expectedStatus: http.StatusUnauthorized},
+		{name: "다른 키로 만든 proof", authorization: "DPoP " + boundToken, proof: proof(otherKey, boundToken), expectedStatus: http.StatusUnauthorized},
+		{name: "바인딩 되지 않은 토큰을 DPoP 로 전달", authorization: "DPoP " + bearerToken, proof: proof(key, bearerToken), expectedStatus: http.StatusUnauthorized},
+		{name: "바인딩 되지 않은 Bearer 토큰", authorization: "Bearer " + bearerToken, expectedStatus: http.StatusOK},
+		{name: "DPoP 필수인 경우 Bearer 토큰 거부", authorization: "Bearer " + bearerToken, required: true, expectedStatus: http.StatusUnauthorized},
+		{name: "DPoP, Bearer 가 아닌 scheme", authorization: "Basic " + bearerToken, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			opt := WithDPoP(NewDPoPValidator())
+			if tc.required {
+				opt = WithDPoPRequired(NewDPoPValidator())
+			}
+			m := NewJwtMiddleware(DPoPHeaderExtractor, NewValidator[jwt.Claims](config), nil, &dpopTestClaims{}, opt)
+
+			r := httptest.NewRequest(http.MethodGet, htu, nil)
+			r.Header.Set("Authorization", tc.authorization)
+			if tc.proof != "" {
+				r.Header.Set(DPoPHeader, tc.proof)
+			}
+
+			w := httptest.NewRecorder()
+			m.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, r)
+			assert.Equal(t, tc.expectedStatus, w.Code)
+		})
+	}
+
+	// cnf 를 디코딩하지 않는 클레임 타입도 토큰 페이로드의 cnf.jkt 로 바인딩 확인
+	prototypes := map[string]jwt.Claims{
+		"RegisteredClaims": &jwt.RegisteredClaims{},
+		"MapClaims":        jwt.MapClaims{},
+	}
+	for name, prototype := range prototypes {
+		prototype := prototype
+		t.Run(name+" 클레임 타입", func(t *testing.T) {
+			var got error
+			m := NewJwtMiddleware(DPoPHeaderExtractor, NewValidator[jwt.Claims](config), func(w http.ResponseWriter, r *http.Request, err error) {
+				got = err
+				w.WriteHeader(http.StatusUnauthorized)
+			}, prototype, WithDPoP(NewDPoPValidator()))
+			h := m.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			r := httptest.NewRequest(http.MethodGet, htu, nil)
+			r.Header.Set("Authorization", "Bearer "+boundToken)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.ErrorIs(t, got, ErrDPoPProofMissing)
+
+			r = httptest.NewRequest(http.MethodGet, htu, nil)
+			r.Header.Set("Authorization", "DPoP "+boundToken)
+			r.Header.Set(DPoPHeader, proof(otherKey, boundToken))
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.ErrorIs(t, got, ErrDPoPBindingMismatch)
+
+			r = httptest.NewRequest(http.MethodGet, htu, nil)
+			r.Header.Set("Authorization", "DPoP "+boundToken)
+			r.Header.Set(DPoPHeader, proof(key, boundToken))
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
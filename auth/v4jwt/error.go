@@ -1,10 +1,12 @@
 package v4jwt
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/token"
 )
 
 /**
@@ -41,30 +43,77 @@ var (
 )
 
 var (
-	ErrJwtMissing = errors.New("missing jwt token")
+	// ErrJwtMissing: errors.Is 로 token.ErrTokenMissing 과도 일치
+	ErrJwtMissing error = &tokenError{err: errors.New("missing jwt token"), kinds: []error{token.ErrTokenMissing}}
+	ErrCertificateBindingMismatch = errors.New("access token is not bound to the client certificate")
+	// ErrDPoPAuthHeaderMalformed: errors.Is 로 token.ErrAuthHeaderMalformed 와도 일치
+	ErrDPoPAuthHeaderMalformed error = &tokenError{err: errors.New("Authorization header format must be DPoP {token} or Bearer {token}"), kinds: []error{token.ErrAuthHeaderMalformed}}
 )
 
-type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error) 
+type ErrorHandler = token.ErrorHandler
 
 func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	status, message, challenge := errorResponse(err)
+
 	w.Header().Set("Content-Type", "application/json")
+	if challenge != "" {
+		w.Header().Set("WWW-Authenticate", challenge)
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(`{"message": "` + message + `"}`))
+}
+
+// ProblemErrorHandler: DefaultErrorHandler 와 같은 상태 코드를 RFC 9457 problem details 형식으로 응답
+func ProblemErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	status, message, challenge := errorResponse(err)
 
-	switch { 
+	w.Header().Set("Content-Type", "application/problem+json")
+	if challenge != "" {
+		w.Header().Set("WWW-Authenticate", challenge)
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Type   string `json:"type"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+		Detail string `json:"detail"`
+	}{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: message,
+	})
+}
+
+// errorResponse: 에러에 해당하는 상태 코드, 메시지, WWW-Authenticate 헤더
+func errorResponse(err error) (int, string, string) {
+	switch {
 	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(`{"message": "invalid token signature"}`))
+		return http.StatusUnauthorized, "invalid token signature", ""
 	case errors.Is(err, jwt.ErrTokenExpired) || errors.Is(err, jwt.ErrTokenNotValidYet):
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(`{"message": "token is expired or not valid yet"}`))
+		return http.StatusUnauthorized, "token is expired or not valid yet", ""
 	case errors.Is(err, jwt.ErrTokenMalformed):
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(`{"message": "invalid token format"}`))
-	case errors.Is(err, ErrJwtMissing):
-		w.WriteHeader(http.StatusBadGateway) 
-		_, _ = w.Write([]byte(`{"message": "missing jwt token"}`))
+		return http.StatusUnauthorized, "invalid token format", ""
+	case errors.Is(err, ErrUnknownIssuer) || errors.Is(err, ErrKeyNotFound):
+		return http.StatusUnauthorized, "untrusted token issuer", ""
+	case errors.Is(err, ErrTokenInvalidIssuer) || errors.Is(err, ErrTokenInvalidAudience) || errors.Is(err, ErrAccessTokenClaimMissing):
+		return http.StatusUnauthorized, "invalid token claims", ""
+	case errors.Is(err, ErrActionTokenMisuse) || errors.Is(err, ErrTokenTypeMismatch):
+		return http.StatusUnauthorized, "invalid token type", ""
+	case errors.Is(err, ErrTokenReplayed):
+		return http.StatusUnauthorized, "token has already been used", ""
+	case errors.Is(err, ErrDPoPProofMissing) || errors.Is(err, ErrDPoPProofInvalid) || errors.Is(err, ErrDPoPProofReplayed):
+		return http.StatusUnauthorized, "invalid dpop proof", `DPoP error="invalid_dpop_proof"`
+	case errors.Is(err, ErrDPoPBindingMismatch):
+		return http.StatusUnauthorized, "token is not bound to the dpop key", `DPoP error="invalid_token"`
+	case errors.Is(err, ErrCertificateBindingMismatch):
+		return http.StatusUnauthorized, "token is not bound to the client certificate", `Bearer error="invalid_token"`
+	case errors.Is(err, token.ErrTokenMissing):
+		// token 패키지와 같은 응답, 인증 정보가 없는 요청에는 에러 코드를 포함하지 않음
+		return http.StatusUnauthorized, "missing token", "Bearer"
+	case errors.Is(err, token.ErrAuthHeaderMalformed):
+		return http.StatusUnauthorized, "invalid authorization header", `Bearer error="invalid_request"`
 	default:
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = w.Write([]byte(`{"message": "internal server error in jwt"}`))
+		return http.StatusInternalServerError, "internal server error in jwt", ""
 	}
-
-}
\ No newline at end of file
+}
@@ -1,39 +1,55 @@
 package v4jwt
 
 import (
-	"errors"
 	"net/http"
 	"strings"
+
+	"github.com/nookcoder/go-boilerplate/auth/token"
 )
 
 // Extractor: 토큰을 추출하는 함수 타입
 // 만약 헤더나 토큰이 빈 문자열인 경우 에러로 인식하지 않고 nil 반환
-type Extractor func(r *http.Request) (string, error) 
+type Extractor = token.Extractor
 
 // AuthHeaderExtractor: Authorization: Bearer <token> 에서 token 추출 
-func AuthHeaderExtractor(r *http.Request) (string, error) { 
-	authHeader := r.Header.Get("Authorization") 
+func AuthHeaderExtractor(r *http.Request) (string, error) {
+	return token.AuthHeaderExtractor(r)
+}
+
+// CookieExtractor: 쿠키에서 토큰 추출
+func CookieExtractor(cookieName string) Extractor {
+	return token.CookieExtractor(cookieName)
+}
+
+// DPoPHeaderExtractor: Authorization: DPoP <token> 또는 Bearer <token> 에서 token 추출
+// Bearer 로 전달된 DPoP 바인딩 토큰은 JwtMiddleware 의 WithDPoP 옵션에서 거부
+func DPoPHeaderExtractor(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
 	if authHeader == "" {
-		return "", nil 
+		return "", nil
 	}
 
-	authHeaderParts := strings.Split(authHeader, " ") 
-	if len(authHeaderParts) != 2 || strings.ToLower(authHeaderParts[0]) != "bearer" {
-		return "", errors.New("Authorization header format must be Bearer {token}")
-	} 
+	authHeaderParts := strings.Split(authHeader, " ")
+	if len(authHeaderParts) != 2 {
+		return "", ErrDPoPAuthHeaderMalformed
+	}
 
-	return authHeaderParts[1], nil
+	switch strings.ToLower(authHeaderParts[0]) {
+	case "dpop", "bearer":
+		return authHeaderParts[1], nil
+	default:
+		return "", ErrDPoPAuthHeaderMalformed
+	}
 }
 
-// CookieExtractor: 쿠키에서 토큰 추출
-func CookieExtractor(cookieName string) Extractor {
-	return func(r *http.Request) (string, error) {
-		cookie, err := r.Cookie(cookieName)
-		// 쿠키가 없는 경우 빈 문자열 반환
-		if err != nil {
-			return "", nil
-		}
-
-		return cookie.Value, nil 
-	}
+// MultiExtractor: 순서대로 시도하여 처음으로 찾은 토큰 반환
+// 추출 중 에러가 발생하면 다음 extractor 를 시도하지 않음
+func MultiExtractor(extractors ...Extractor) Extractor {
+	return token.MultiExtractor(extractors...)
+}
+
+// authScheme: Authorization 헤더의 scheme (소문자)
+func authScheme(r *http.Request) string {
+	scheme, _, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	return strings.ToLower(scheme)
 }
\ No newline at end of file
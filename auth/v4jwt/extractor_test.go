@@ -101,4 +101,41 @@ func TestCookieExtractor(t *testing.T) {
 			assert.NoError(t, err)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestMultiExtractor(t *testing.T) {
+	extractor := MultiExtractor(AuthHeaderExtractor, CookieExtractor("access_token"))
+
+	testCases := []struct {
+		name          string
+		header        string
+		cookie        string
+		expectedToken string
+		expectedError bool
+	}{
+		{name: "헤더 토큰 우선", header: "Bearer header-token", cookie: "cookie-token", expectedToken: "header-token"},
+		{name: "헤더가 없으면 쿠키 사용", cookie: "cookie-token", expectedToken: "cookie-token"},
+		{name: "모두 없는 경우", expectedToken: ""},
+		{name: "헤더 형식 오류는 쿠키를 시도하지 않음", header: "Basic abc", cookie: "cookie-token", expectedError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &http.Request{Header: http.Header{}}
+			if tc.header != "" {
+				r.Header.Set("Authorization", tc.header)
+			}
+			if tc.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "access_token", Value: tc.cookie})
+			}
+
+			token, err := extractor(r)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedToken, token)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package v4jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWK(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		key  crypto.PublicKey
+		kty  string
+	}{
+		{name: "RSA 공개키", key: &rsaKey.PublicKey, kty: "RSA"},
+		{name: "ECDSA P-384 공개키", key: &ecKey.PublicKey, kty: "EC"},
+		{name: "Ed25519 공개키", key: edPub, kty: "OKP"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			jwk, err := NewJWK(tc.key, "kid-1", "")
+			require.NoError(t, err)
+			assert.Equal(t, tc.kty, jwk.Kty)
+
+			// JSON 직렬화 후에도 같은 키로 복원되어야 함
+			b, err := json.Marshal(jwk)
+			require.NoError(t, err)
+			var decoded JWK
+			require.NoError(t, json.Unmarshal(b, &decoded))
+
+			key, err := decoded.PublicKey()
+			require.NoError(t, err)
+			assert.True(t, key.(interface{ Equal(crypto.PublicKey) bool }).Equal(tc.key))
+		})
+	}
+
+	t.Run("지원하지 않는 키 타입", func(t *testing.T) {
+		_, err := NewJWK([]byte("secret"), "kid", "HS256")
+		assert.ErrorIs(t, err, ErrUnsupportedKey)
+	})
+}
+
+func TestJWKSHandler(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks, err := NewJWKS(
+		NewKeyPairConfig(jwt.SigningMethodRS256, rsaKey, &rsaKey.PublicKey, WithKeyID("rsa-1")),
+		NewConfig(jwt.SigningMethodHS256, []byte("secret")),
+	)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	JWKSHandler(jwks).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got JWKS
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	// HMAC 키는 공개되지 않아야 함
+	require.Len(t, got.Keys, 1)
+
+	jwk, err := got.Key(context.Background(), "rsa-1")
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", jwk.Alg)
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	// RFC 7638 Section 3.1 예제
+	jwk := JWK{
+		Kty: "RSA",
+		N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		E:   "AQAB",
+		Alg: "RS256",
+		Kid: "2011-04-29",
+	}
+
+	thumbprint, err := jwk.Thumbprint()
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint)
+}
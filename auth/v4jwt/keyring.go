@@ -0,0 +1,224 @@
+package v4jwt
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	defaultSecretRefreshInterval = 5 * time.Minute
+	defaultSecretGracePeriod     = time.Hour
+	minSecretRefreshInterval     = time.Second
+)
+
+var ErrSecretExpired = errors.New("secret lease has expired")
+
+type ringSecret struct {
+	kid   string
+	value []byte
+	// expiresAt: 0 이면 기간 제한 없음
+	expiresAt time.Time
+}
+
+func (s *ringSecret) expired(now time.Time) bool {
+	return !s.expiresAt.IsZero() && !now.Before(s.expiresAt)
+}
+
+// SecretKeyRing: SecretProvider 의 secret 을 버전별로 보관하는 HMAC 키 링
+// 현재 버전으로 서명하고 버전을 kid 로 사용하며,
+// 교체된 이전 버전은 gracePeriod 동안 검증에만 사용하여 이미 발급된 토큰이 바로 무효화되지 않도록 함
+type SecretKeyRing struct {
+	provider        SecretProvider
+	refreshInterval time.Duration
+	gracePeriod     time.Duration
+	now             func() time.Time
+	auditLogger     *slog.Logger
+
+	mu      sync.RWMutex
+	current *ringSecret
+	retired []*ringSecret
+}
+
+type KeyRingOption func(*SecretKeyRing)
+
+// WithSecretRefreshInterval: Run 에서 secret 을 다시 가져오는 간격
+// 임대 기간이 있는 secret 은 남은 기간의 2/3 이 지나기 전에 다시 가져옴
+func WithSecretRefreshInterval(interval time.Duration) KeyRingOption {
+	return func(r *SecretKeyRing) {
+		r.refreshInterval = interval
+	}
+}
+
+// WithSecretGracePeriod: 교체된 secret 으로 서명된 토큰을 계속 허용하는 기간
+// 발급하는 토큰의 최대 유효 기간 이상으로 설정
+func WithSecretGracePeriod(period time.Duration) KeyRingOption {
+	return func(r *SecretKeyRing) {
+		r.gracePeriod = period
+	}
+}
+
+// WithKeyRingAuditLogger: secret 교체와 갱신 실패 이벤트 기록
+func WithKeyRingAuditLogger(logger *slog.Logger) KeyRingOption {
+	return func(r *SecretKeyRing) {
+		r.auditLogger = logger
+	}
+}
+
+// NewSecretKeyRing: 시작 시 secret 을 가져오며, 실패하면 에러
+func NewSecretKeyRing(ctx context.Context, provider SecretProvider, opts ...KeyRingOption) (*SecretKeyRing, error) {
+	r := &SecretKeyRing{
+		provider:        provider,
+		refreshInterval: defaultSecretRefreshInterval,
+		gracePeriod:     defaultSecretGracePeriod,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if err := r.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewKeyRingConfig: SecretKeyRing 의 현재 secret 으로 서명, 검증하는 HMAC 설정
+func NewKeyRingConfig(method jwt.SigningMethod, ring *SecretKeyRing, opts ...ConfigOption) *Config {
+	c := &Config{
+		method:  method,
+		keyRing: ring,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Refresh: secret 을 다시 가져와 버전이 바뀌었으면 교체
+// 실패하면 기존 secret 을 그대로 사용
+func (r *SecretKeyRing) Refresh(ctx context.Context) error {
+	secret, err := r.provider.Secret(ctx)
+	if err != nil {
+		if r.auditLogger != nil {
+			auditSecretRefreshFailed(r.auditLogger, err)
+		}
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	next := &ringSecret{kid: secret.Version, value: secret.Value}
+	if secret.LeaseDuration > 0 {
+		next.expiresAt = now.Add(secret.LeaseDuration)
+	}
+
+	previous := r.current
+	r.current = next
+	if previous == nil {
+		return nil
+	}
+	if previous.kid == next.kid {
+		// 같은 버전이면 임대 기간만 갱신
+		return nil
+	}
+
+	retiredUntil := now.Add(r.gracePeriod)
+	if previous.expiresAt.IsZero() || retiredUntil.Before(previous.expiresAt) {
+		previous.expiresAt = retiredUntil
+	}
+
+	// 이전 버전으로 되돌린 경우 retired 에서 제거하여 중복되지 않도록 함
+	retired := []*ringSecret{previous}
+	var removed []string
+	for _, s := range r.retired {
+		if s.kid == next.kid || s.kid == previous.kid {
+			continue
+		}
+		if s.expired(now) {
+			removed = append(removed, s.kid)
+			continue
+		}
+		retired = append(retired, s)
+	}
+	r.retired = retired
+
+	if r.auditLogger != nil {
+		auditSecretRotated(r.auditLogger, previous.kid, next.kid, removed, now)
+	}
+	return nil
+}
+
+// Run: ctx 가 끝날 때까지 주기적으로 Refresh 호출
+// 갱신 실패는 WithKeyRingAuditLogger 로 기록하고 다음 주기에 다시 시도
+func (r *SecretKeyRing) Run(ctx context.Context) error {
+	timer := time.NewTimer(r.nextRefresh())
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			_ = r.Refresh(ctx)
+			timer.Reset(r.nextRefresh())
+		}
+	}
+}
+
+// nextRefresh: 임대 기간이 남아 있으면 만료 전에 갱신되도록 간격을 줄임
+func (r *SecretKeyRing) nextRefresh() time.Duration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	interval := r.refreshInterval
+	if r.current != nil && !r.current.expiresAt.IsZero() {
+		remaining := r.current.expiresAt.Sub(r.now()) * 2 / 3
+		if remaining > 0 && remaining < interval {
+			interval = remaining
+		}
+	}
+	if interval < minSecretRefreshInterval {
+		interval = minSecretRefreshInterval
+	}
+	return interval
+}
+
+// KeyID: 현재 secret 의 버전
+func (r *SecretKeyRing) KeyID() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current.kid
+}
+
+// SigningKey: 서명에 사용할 현재 secret 과 kid
+// 임대 기간이 지났는데 갱신하지 못한 경우 ErrSecretExpired
+func (r *SecretKeyRing) SigningKey() ([]byte, string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.current.expired(r.now()) {
+		return nil, "", ErrSecretExpired
+	}
+	return r.current.value, r.current.kid, nil
+}
+
+// VerifyKey: kid 에 해당하는 secret
+// kid 가 비어있으면 현재 secret, 교체된 secret 은 gracePeriod 안에서만 반환
+func (r *SecretKeyRing) VerifyKey(kid string) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if kid == "" || kid == r.current.kid {
+		return r.current.value, nil
+	}
+	now := r.now()
+	for _, s := range r.retired {
+		if s.kid == kid && !s.expired(now) {
+			return s.value, nil
+		}
+	}
+	return nil, ErrKeyNotFound
+}
@@ -0,0 +1,119 @@
+package v4jwt
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type secretProviderFunc func(ctx context.Context) (*Secret, error)
+
+func (f secretProviderFunc) Secret(ctx context.Context) (*Secret, error) {
+	return f(ctx)
+}
+
+func TestSecretKeyRing(t *testing.T) {
+	kv, server := newFakeVaultKV(t, strings.Repeat("1", 32))
+	provider := NewVaultKVProvider(server.URL, "secret", "jwt", WithVaultToken(testVaultToken))
+
+	now := time.Now()
+	ring, err := NewSecretKeyRing(context.Background(), provider, WithSecretGracePeriod(10*time.Minute))
+	require.NoError(t, err)
+	ring.now = func() time.Time { return now }
+
+	config := NewKeyRingConfig(jwt.SigningMethodHS256, ring)
+	creator := NewCreator(config)
+	validator := NewValidator[*jwt.RegisteredClaims](config)
+	newToken := func() string {
+		token, err := creator.CreateToken(&jwt.RegisteredClaims{
+			Subject:   "user-1",
+			ExpiresAt: jwt.NewNumericDate(jwt.TimeFunc().Add(time.Hour)),
+		})
+		require.NoError(t, err)
+		return token
+	}
+	kidOf := func(token string) string {
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+		require.NoError(t, err)
+		return parsed.Header["kid"].(string)
+	}
+
+	first := newToken()
+	assert.Equal(t, "1", kidOf(first))
+	assert.Equal(t, "1", config.KeyID())
+
+	// 새 버전으로 교체되면 새 버전으로 서명하고, 이전 버전 토큰도 계속 허용
+	kv.put(map[string]string{"secret": strings.Repeat("2", 32)})
+	require.NoError(t, ring.Refresh(context.Background()))
+	second := newToken()
+	assert.Equal(t, "2", kidOf(second))
+
+	for _, token := range []string{first, second} {
+		_, err := validator.ValidateToken(token, &jwt.RegisteredClaims{})
+		assert.NoError(t, err)
+	}
+
+	// gracePeriod 가 지나면 이전 버전 토큰은 거부
+	now = now.Add(11 * time.Minute)
+	_, err = validator.ValidateToken(first, &jwt.RegisteredClaims{})
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Equal(t, "unknown_key", ErrorClass(err))
+	_, err = validator.ValidateToken(second, &jwt.RegisteredClaims{})
+	assert.NoError(t, err)
+
+	t.Run("같은 버전이면 교체하지 않음", func(t *testing.T) {
+		require.NoError(t, ring.Refresh(context.Background()))
+		assert.Equal(t, "2", config.KeyID())
+		_, err := validator.ValidateToken(second, &jwt.RegisteredClaims{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("임대 기간이 지나면 서명 거부", func(t *testing.T) {
+		kv.mu.Lock()
+		kv.leaseDuration = 60
+		kv.mu.Unlock()
+		require.NoError(t, ring.Refresh(context.Background()))
+		assert.Equal(t, 40*time.Second, ring.nextRefresh())
+
+		now = now.Add(2 * time.Minute)
+		_, err := creator.CreateToken(&jwt.RegisteredClaims{Subject: "user-1"})
+		assert.ErrorIs(t, err, ErrSecretExpired)
+
+		// 갱신되면 다시 서명
+		require.NoError(t, ring.Refresh(context.Background()))
+		newToken()
+	})
+}
+
+func TestSecretKeyRingRefreshError(t *testing.T) {
+	secret := &Secret{Value: []byte(strings.Repeat("k", 32)), Version: "v1"}
+	var fetchErr error
+	provider := secretProviderFunc(func(ctx context.Context) (*Secret, error) {
+		return secret, fetchErr
+	})
+
+	ring, err := NewSecretKeyRing(context.Background(), provider)
+	require.NoError(t, err)
+
+	// 갱신에 실패해도 기존 secret 을 계속 사용
+	fetchErr = errors.New("vault unavailable")
+	assert.ErrorIs(t, ring.Refresh(context.Background()), fetchErr)
+	key, kid, err := ring.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, secret.Value, key)
+	assert.Equal(t, "v1", kid)
+
+	// 시작 시 가져오지 못하면 에러
+	_, err = NewSecretKeyRing(context.Background(), provider)
+	assert.ErrorIs(t, err, fetchErr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, ring.Run(ctx), context.Canceled)
+}
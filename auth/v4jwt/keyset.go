@@ -0,0 +1,155 @@
+package v4jwt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultKeySetRefreshInterval = time.Minute
+	defaultKeySetTimeout         = 10 * time.Second
+)
+
+// RemoteKeySet: jwks_uri 에서 JWKS 를 가져와 캐시하는 KeySet
+// 모르는 kid 가 들어오면 키 교체로 보고 다시 가져오되, refreshInterval 안에는 재요청하지 않음
+// 가져오는 동안 잠금을 잡지 않아 캐시된 키의 조회는 막히지 않고, 동시에 들어온 요청은 하나의 요청을 기다림
+type RemoteKeySet struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+
+	mu        sync.Mutex
+	jwks      *JWKS
+	fetchedAt time.Time
+	// fetchErr: 마지막 요청의 에러
+	fetchErr error
+	// fetching: 진행 중인 요청이 끝나면 닫히는 채널
+	fetching chan struct{}
+	now      func() time.Time
+
+	auditLogger *slog.Logger
+}
+
+type RemoteKeySetOption func(*RemoteKeySet)
+
+// WithHTTPClient: JWKS 요청에 사용할 클라이언트, 기본값은 10초 timeout 의 클라이언트
+func WithHTTPClient(client *http.Client) RemoteKeySetOption {
+	return func(s *RemoteKeySet) {
+		s.client = client
+	}
+}
+
+// WithRefreshInterval: 모르는 kid 로 인한 재요청 최소 간격
+func WithRefreshInterval(interval time.Duration) RemoteKeySetOption {
+	return func(s *RemoteKeySet) {
+		s.refreshInterval = interval
+	}
+}
+
+func NewRemoteKeySet(url string, opts ...RemoteKeySetOption) *RemoteKeySet {
+	s := &RemoteKeySet{
+		url:             url,
+		client:          &http.Client{Timeout: defaultKeySetTimeout},
+		refreshInterval: defaultKeySetRefreshInterval,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *RemoteKeySet) Key(ctx context.Context, keyID string) (JWK, error) {
+	waited := false
+	for {
+		s.mu.Lock()
+		if s.jwks != nil {
+			if key, err := s.jwks.Key(ctx, keyID); err == nil {
+				s.mu.Unlock()
+				return key, nil
+			}
+		}
+
+		// 진행 중인 요청이 있으면 기다린 뒤 다시 조회
+		if fetching := s.fetching; fetching != nil {
+			s.mu.Unlock()
+			select {
+			case <-fetching:
+				waited = true
+				continue
+			case <-ctx.Done():
+				return JWK{}, ctx.Err()
+			}
+		}
+
+		// 기다린 요청의 결과에도 없으면 다시 요청하지 않음
+		// 실패한 요청도 재요청 간격에 포함하여 장애 시 provider 에 요청이 몰리지 않도록 함
+		if waited || (!s.fetchedAt.IsZero() && s.now().Sub(s.fetchedAt) < s.refreshInterval) {
+			err := ErrKeyNotFound
+			if s.jwks == nil && s.fetchErr != nil {
+				err = s.fetchErr
+			}
+			s.mu.Unlock()
+			return JWK{}, err
+		}
+
+		fetching := make(chan struct{})
+		s.fetching = fetching
+		s.mu.Unlock()
+
+		// 요청한 쪽이 취소되어도 기다리는 다른 요청을 위해 끝까지 가져옴, 시간 제한은 클라이언트의 timeout
+		go s.refresh(context.WithoutCancel(ctx), fetching)
+	}
+}
+
+// refresh: JWKS 를 가져와 반영하고 fetching 을 닫음
+func (s *RemoteKeySet) refresh(ctx context.Context, fetching chan struct{}) {
+	startedAt := s.now()
+	jwks, err := s.fetch(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// 취소된 요청은 provider 의 장애가 아니므로 재요청 간격과 에러에 반영하지 않음
+	if !errors.Is(err, context.Canceled) {
+		s.fetchedAt = startedAt
+		s.fetchErr = err
+	}
+	if err == nil {
+		if s.auditLogger != nil {
+			auditKeyRotated(s.auditLogger, s.url, s.jwks, jwks, startedAt)
+		}
+		s.jwks = jwks
+	}
+	s.fetching = nil
+	close(fetching)
+}
+
+// fetch: 잠금 없이 호출하며 결과의 반영은 호출하는 쪽에서 처리
+func (s *RemoteKeySet) fetch(ctx context.Context) (*JWKS, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var jwks JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+	return &jwks, nil
+}
@@ -0,0 +1,186 @@
+package v4jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteKeySet(t *testing.T) {
+	pub1, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pub2, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwk1, err := NewJWK(pub1, "key-1", "EdDSA")
+	require.NoError(t, err)
+	jwk2, err := NewJWK(pub2, "key-2", "EdDSA")
+	require.NoError(t, err)
+
+	var requests int32
+	var current atomic.Pointer[JWKS]
+	current.Store(&JWKS{Keys: []JWK{jwk1}})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_ = json.NewEncoder(w).Encode(current.Load())
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	keys := NewRemoteKeySet(server.URL)
+	keys.now = func() time.Time { return now }
+
+	key, err := keys.Key(context.Background(), "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, jwk1, key)
+
+	// 캐시된 키는 다시 요청하지 않음
+	_, err = keys.Key(context.Background(), "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// 키 교체 직후 refreshInterval 안에는 재요청하지 않음
+	current.Store(&JWKS{Keys: []JWK{jwk1, jwk2}})
+	_, err = keys.Key(context.Background(), "key-2")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// refreshInterval 이 지나면 모르는 kid 에 대해 다시 가져옴
+	now = now.Add(2 * defaultKeySetRefreshInterval)
+	key, err = keys.Key(context.Background(), "key-2")
+	require.NoError(t, err)
+	assert.Equal(t, jwk2, key)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestRemoteKeySetFailure(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	keys := NewRemoteKeySet(server.URL)
+	keys.now = func() time.Time { return now }
+	assert.NotZero(t, keys.client.Timeout, "기본 클라이언트는 timeout 이 있어야 함")
+
+	// 한 번도 가져오지 못한 경우에도 refreshInterval 안에는 재요청하지 않음
+	for i := 0; i < 10; i++ {
+		_, err := keys.Key(context.Background(), "key-1")
+		assert.ErrorContains(t, err, "unexpected status 500")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	now = now.Add(2 * defaultKeySetRefreshInterval)
+	_, err := keys.Key(context.Background(), "key-1")
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestRemoteKeySetConcurrentFetch(t *testing.T) {
+	pub1, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pub2, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	jwk1, err := NewJWK(pub1, "key-1", "EdDSA")
+	require.NoError(t, err)
+	jwk2, err := NewJWK(pub2, "key-2", "EdDSA")
+	require.NoError(t, err)
+
+	var requests int32
+	var current atomic.Pointer[JWKS]
+	current.Store(&JWKS{Keys: []JWK{jwk1}})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) > 1 {
+			<-release
+		}
+		_ = json.NewEncoder(w).Encode(current.Load())
+	}))
+	defer server.Close()
+
+	keys := NewRemoteKeySet(server.URL, WithRefreshInterval(0))
+	_, err = keys.Key(context.Background(), "key-1")
+	require.NoError(t, err)
+
+	// 두 번째 요청은 release 를 닫을 때까지 응답하지 않음
+	current.Store(&JWKS{Keys: []JWK{jwk1, jwk2}})
+	const callers = 10
+	results := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			key, err := keys.Key(context.Background(), "key-2")
+			if err == nil && key.Kid != "key-2" {
+				err = ErrKeyNotFound
+			}
+			results <- err
+		}()
+	}
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 2 }, time.Second, time.Millisecond)
+
+	// 가져오는 동안에도 캐시된 키는 바로 조회
+	key, err := keys.Key(context.Background(), "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, jwk1, key)
+
+	close(release)
+	for i := 0; i < callers; i++ {
+		assert.NoError(t, <-results)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestRemoteKeySetCanceledCaller(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	jwk, err := NewJWK(pub, "key-1", "EdDSA")
+	require.NoError(t, err)
+
+	var requests int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		_ = json.NewEncoder(w).Encode(&JWKS{Keys: []JWK{jwk}})
+	}))
+	defer server.Close()
+
+	keys := NewRemoteKeySet(server.URL)
+
+	// 요청을 시작한 호출이 취소되어도 기다리는 호출은 가져온 키를 받음
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := keys.Key(ctx, "key-1")
+		canceled <- err
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 1 }, time.Second, time.Millisecond)
+
+	waiter := make(chan error, 1)
+	go func() {
+		_, err := keys.Key(context.Background(), "key-1")
+		waiter <- err
+	}()
+
+	cancel()
+	assert.ErrorIs(t, <-canceled, context.Canceled)
+
+	close(release)
+	assert.NoError(t, <-waiter)
+
+	key, err := keys.Key(context.Background(), "key-1")
+	require.NoError(t, err)
+	assert.Equal(t, jwk, key)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
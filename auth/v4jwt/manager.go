@@ -32,7 +32,33 @@ func (m *TokenManager[T]) CreateToken(claims jwt.Claims) (string, error) {
 	return m.Creator.CreateToken(claims)
 }
 
+// CreateTypedToken: Creator 가 TypedTokenCreator 가 아니면 ErrTokenTypeUnsupported 반환
+func (m *TokenManager[T]) CreateTypedToken(claims jwt.Claims, typ string) (string, error) {
+	creator, ok := m.Creator.(TypedTokenCreator)
+	if !ok {
+		return "", ErrTokenTypeUnsupported
+	}
+	return creator.CreateTypedToken(claims, typ)
+}
+
 func (m *TokenManager[T]) ValidateToken(tokenString string, claims T) (T, error) {
 	return m.Validator.ValidateToken(tokenString, claims)
 }
 
+// ClaimsValidator: TokenValidator[T] 를 JwtMiddleware 에서 사용하는 TokenValidator[jwt.Claims] 로 변환
+// 미들웨어의 클레임 타입이 T 와 다른 경우 ErrTokenInvalidClaims 반환
+func ClaimsValidator[T jwt.Claims](validator TokenValidator[T]) TokenValidator[jwt.Claims] {
+	return &claimsValidator[T]{validator: validator}
+}
+
+type claimsValidator[T jwt.Claims] struct {
+	validator TokenValidator[T]
+}
+
+func (v *claimsValidator[T]) ValidateToken(tokenString string, claims jwt.Claims) (jwt.Claims, error) {
+	c, ok := claims.(T)
+	if !ok {
+		return nil, ErrTokenInvalidClaims
+	}
+	return v.validator.ValidateToken(tokenString, c)
+}
@@ -0,0 +1,114 @@
+package v4jwt
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Metrics 의 operation 라벨
+const (
+	OperationValidate   = "validate"
+	OperationMiddleware = "middleware"
+)
+
+// Metrics: 토큰 발급, 검증 계측 인터페이스
+// 구현체는 여러 고루틴에서 동시에 호출됨
+type Metrics interface {
+	// TokenIssued: Creator 의 토큰 발급 성공
+	TokenIssued(alg string)
+	// TokenValidated: 검증 성공과 소요 시간
+	TokenValidated(operation, alg string, duration time.Duration)
+	// TokenRejected: 검증 실패와 ErrorClass 로 분류한 사유, 소요 시간
+	TokenRejected(operation, alg, reason string, duration time.Duration)
+}
+
+// WithMetrics: 이 설정을 사용하는 Creator, Validator 를 계측
+func WithMetrics(metrics Metrics) ConfigOption {
+	return func(c *Config) {
+		c.metrics = metrics
+	}
+}
+
+// WithRequestMetrics: JwtMiddleware 의 요청 인증 결과 계측
+func WithRequestMetrics(metrics Metrics) MiddlewareOption {
+	return func(m *JwtMiddleware) {
+		m.metrics = metrics
+	}
+}
+
+// ErrorClass: 검증 에러를 메트릭, 로그 라벨로 사용할 수 있는 고정된 값으로 분류
+func ErrorClass(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrJwtMissing):
+		return "missing"
+	case errors.Is(err, ErrTokenMalformed):
+		return "malformed"
+	case errors.Is(err, ErrTokenSignatureInvalid):
+		return "signature"
+	case errors.Is(err, ErrTokenExpired):
+		return "expired"
+	case errors.Is(err, ErrTokenNotValidYet) || errors.Is(err, ErrTokenUsedBeforeIssued):
+		return "not_valid_yet"
+	case errors.Is(err, ErrTokenTypeMismatch) || errors.Is(err, ErrActionTokenMisuse):
+		return "type"
+	case errors.Is(err, ErrUnknownIssuer):
+		return "unknown_issuer"
+	case errors.Is(err, ErrKeyNotFound):
+		return "unknown_key"
+	case errors.Is(err, ErrTokenInvalidIssuer):
+		return "issuer"
+	case errors.Is(err, ErrTokenInvalidAudience):
+		return "audience"
+	case errors.Is(err, ErrTokenReplayed):
+		return "replayed"
+	case errors.Is(err, ErrDPoPProofMissing) || errors.Is(err, ErrDPoPProofInvalid) || errors.Is(err, ErrDPoPProofReplayed):
+		return "dpop"
+	case errors.Is(err, ErrDPoPBindingMismatch) || errors.Is(err, ErrCertificateBindingMismatch):
+		return "binding"
+	case errors.Is(err, ErrTokenUnverifiable):
+		return "unverifiable"
+	case errors.Is(err, ErrTokenInvalidClaims) || errors.Is(err, ErrTokenInvalidId) || errors.Is(err, ErrAccessTokenClaimMissing):
+		return "claims"
+	default:
+		return "other"
+	}
+}
+
+// metricAlg: 메트릭 라벨로 사용할 헤더의 alg, 형식이 잘못된 경우 빈 문자열
+// 서명 검증 전의 값이라 누구나 정할 수 있으므로 등록된 서명 알고리즘이 아니면 "other" 로 묶어서
+// 임의의 alg 로 라벨 수를 늘리지 못하도록 함
+func metricAlg(tokenString string) string {
+	alg := peekHeader(tokenString).Alg
+	if alg == "" || jwt.GetSigningMethod(alg) != nil {
+		return alg
+	}
+	return "other"
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// peekHeader: 서명 검증 없이 헤더 조회, 형식이 잘못된 경우 빈 값
+func peekHeader(tokenString string) tokenHeader {
+	var h tokenHeader
+	header, _, ok := strings.Cut(tokenString, ".")
+	if !ok {
+		return h
+	}
+	b, err := jwt.DecodeSegment(header)
+	if err != nil {
+		return h
+	}
+	if err := json.Unmarshal(b, &h); err != nil {
+		return tokenHeader{}
+	}
+	return h
+}
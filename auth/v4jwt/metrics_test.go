@@ -0,0 +1,110 @@
+package v4jwt
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorClass(t *testing.T) {
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"))
+	validator := NewValidator[*jwt.RegisteredClaims](config, WithExpectedTypes(AccessTokenType))
+
+	expired, err := NewCreator(config, WithTokenType(AccessTokenType)).CreateToken(&jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(jwt.TimeFunc().Add(-time.Minute)),
+	})
+	require.NoError(t, err)
+	untyped, err := NewCreator(config).CreateToken(&jwt.RegisteredClaims{})
+	require.NoError(t, err)
+	forged, err := NewCreator(NewConfig(jwt.SigningMethodHS256, []byte("other")), WithTokenType(AccessTokenType)).CreateToken(&jwt.RegisteredClaims{})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		token    string
+		expected string
+	}{
+		{name: "형식 오류", token: "invalid", expected: "malformed"},
+		{name: "만료", token: expired, expected: "expired"},
+		{name: "typ 불일치", token: untyped, expected: "type"},
+		{name: "서명 오류", token: forged, expected: "signature"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validator.ValidateToken(tc.token, &jwt.RegisteredClaims{})
+			assert.Equal(t, tc.expected, ErrorClass(err))
+		})
+	}
+
+	assert.Equal(t, "missing", ErrorClass(ErrJwtMissing))
+	assert.Equal(t, "", ErrorClass(nil))
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	metrics := NewPrometheusMetrics(0.1, 1)
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"), WithMetrics(metrics))
+	creator := NewCreator(config)
+	validator := NewValidator[jwt.Claims](config)
+
+	token, err := creator.CreateToken(&jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	})
+	require.NoError(t, err)
+
+	_, err = validator.ValidateToken(token, &jwt.RegisteredClaims{})
+	require.NoError(t, err)
+	_, err = validator.ValidateToken("invalid", &jwt.RegisteredClaims{})
+	require.Error(t, err)
+
+	m := NewJwtMiddleware(AuthHeaderExtractor, validator, nil, &jwt.RegisteredClaims{}, WithRequestMetrics(metrics))
+	h := m.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	w := httptest.NewRecorder()
+	metrics.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	expected := []string{
+		"# TYPE jwt_tokens_issued_total counter",
+		`jwt_tokens_issued_total{alg="HS256"} 1`,
+		`jwt_tokens_validated_total{operation="validate",alg="HS256"} 1`,
+		`jwt_tokens_rejected_total{operation="validate",alg="",reason="malformed"} 1`,
+		`jwt_tokens_rejected_total{operation="middleware",alg="",reason="missing"} 1`,
+		"# TYPE jwt_validation_duration_seconds histogram",
+		`jwt_validation_duration_seconds_bucket{operation="validate",outcome="valid",le="1"} 1`,
+		`jwt_validation_duration_seconds_bucket{operation="validate",outcome="valid",le="+Inf"} 1`,
+		`jwt_validation_duration_seconds_count{operation="validate",outcome="rejected"} 1`,
+	}
+	for _, line := range expected {
+		assert.Contains(t, body, line+"\n")
+	}
+}
+
+func TestPrometheusMetricsForgedAlg(t *testing.T) {
+	metrics := NewPrometheusMetrics()
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"), WithMetrics(metrics))
+	validator := NewValidator[jwt.Claims](config)
+
+	// 헤더의 alg 를 매번 다르게 위조한 토큰
+	for i := 0; i < 1000; i++ {
+		header := jwt.EncodeSegment([]byte(fmt.Sprintf(`{"alg":"forged-%d","typ":"JWT"}`, i)))
+		_, err := validator.ValidateToken(header+".e30.c2ln", &jwt.RegisteredClaims{})
+		require.Error(t, err)
+	}
+	_, err := validator.ValidateToken(jwt.EncodeSegment([]byte(`{"alg":"RS256"}`))+".e30.c2ln", &jwt.RegisteredClaims{})
+	require.Error(t, err)
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	assert.Len(t, metrics.rejected, 2)
+	assert.Equal(t, uint64(1000), metrics.rejected[labels("operation", OperationValidate, "alg", "other", "reason", "unverifiable")])
+	assert.Equal(t, uint64(1), metrics.rejected[labels("operation", OperationValidate, "alg", "RS256", "reason", "unverifiable")])
+}
@@ -0,0 +1,95 @@
+package v4jwt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJwtMiddleware(t *testing.T) {
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"))
+	creator := NewCreator(config)
+	validator := NewValidator[jwt.Claims](config)
+
+	newToken := func(userId string) string {
+		token, err := creator.CreateToken(&validateTestClaims{
+			UserId: userId,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext[*validateTestClaims](r.Context())
+		require.True(t, ok)
+		_, _ = w.Write([]byte(claims.UserId))
+	}
+
+	t.Run("요청마다 독립된 클레임 인스턴스 사용", func(t *testing.T) {
+		m := NewJwtMiddleware(AuthHeaderExtractor, validator, nil, &validateTestClaims{})
+		h := m.CheckJwt(http.HandlerFunc(handler))
+
+		for _, userId := range []string{"user-1", "user-2"} {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Authorization", "Bearer "+newToken(userId))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, userId, w.Body.String())
+		}
+	})
+
+	t.Run("전달된 errorHandler 사용", func(t *testing.T) {
+		called := false
+		errorHandler := func(w http.ResponseWriter, r *http.Request, err error) {
+			called = true
+			assert.ErrorIs(t, err, ErrJwtMissing)
+			w.WriteHeader(http.StatusTeapot)
+		}
+		m := NewJwtMiddleware(AuthHeaderExtractor, validator, errorHandler, &validateTestClaims{})
+
+		w := httptest.NewRecorder()
+		m.CheckJwt(http.HandlerFunc(handler)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.True(t, called)
+		assert.Equal(t, http.StatusTeapot, w.Code)
+	})
+
+	t.Run("제외된 요청은 검증 없이 통과", func(t *testing.T) {
+		excluded := false
+		m := NewJwtMiddleware(AuthHeaderExtractor, validator, nil, &validateTestClaims{},
+			WithExclusions(func(r *http.Request) bool { return r.URL.Path == "/healthz" }))
+		h := m.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			excluded = true
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.True(t, excluded)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/private", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("ProblemErrorHandler 응답 형식", func(t *testing.T) {
+		m := NewJwtMiddleware(AuthHeaderExtractor, validator, ProblemErrorHandler, &validateTestClaims{})
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer invalid")
+		w := httptest.NewRecorder()
+		m.CheckJwt(http.HandlerFunc(handler)).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"type": "about:blank", "title": "Unauthorized", "status": 401, "detail": "invalid token format"}`, w.Body.String())
+	})
+}
@@ -2,55 +2,293 @@ package v4jwt
 
 import (
 	"context"
+	"crypto/subtle"
+	"log/slog"
 	"net/http"
+	"reflect"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/token"
 )
 
-type ContextKey struct {}  
+// ContextKey: token.Middleware 와 같은 컨텍스트 키
+type ContextKey = token.ContextKey
 
 type JwtMiddleware struct {
-	extractor Extractor 
-	validator *Validator[jwt.Claims]
+	extractor    Extractor
+	validator    TokenValidator[jwt.Claims]
 	errorHandler ErrorHandler
-	claims jwt.Claims
+	claims       jwt.Claims
+
+	dpop         *DPoPValidator
+	dpopRequired bool
+
+	certificateBinding         bool
+	certificateBindingRequired bool
+
+	metrics     Metrics
+	auditLogger *slog.Logger
+	tracer      Tracer
+
+	exclusions []RequestMatcher
+}
+
+type MiddlewareOption func(*JwtMiddleware)
+
+// RequestMatcher: 요청이 조건에 해당하는지 판단하는 함수
+type RequestMatcher = token.RequestMatcher
+
+// WithExclusions: matcher 중 하나라도 해당하는 요청은 토큰 검증 없이 통과 (health check 등)
+func WithExclusions(matchers ...RequestMatcher) MiddlewareOption {
+	return func(m *JwtMiddleware) {
+		m.exclusions = append(m.exclusions, matchers...)
+	}
+}
+
+// WithDPoP: cnf.jkt 로 바인딩된 토큰은 DPoP scheme 과 일치하는 proof 가 있어야만 통과
+// DPoP scheme 으로 전달된 토큰은 바인딩 되어 있어야 함
+func WithDPoP(validator *DPoPValidator) MiddlewareOption {
+	return func(m *JwtMiddleware) {
+		m.dpop = validator
+	}
+}
+
+// WithDPoPRequired: 바인딩 되지 않은 Bearer 토큰도 거부
+func WithDPoPRequired(validator *DPoPValidator) MiddlewareOption {
+	return func(m *JwtMiddleware) {
+		m.dpop = validator
+		m.dpopRequired = true
+	}
+}
+
+// WithCertificateBinding: cnf.x5t#S256 로 바인딩된 토큰은 TLS 클라이언트 인증서가 일치해야만 통과
+// 서버의 tls.Config.ClientAuth 가 클라이언트 인증서를 요청하도록 설정되어 있어야 함
+func WithCertificateBinding() MiddlewareOption {
+	return func(m *JwtMiddleware) {
+		m.certificateBinding = true
+	}
+}
+
+// WithCertificateBindingRequired: 인증서에 바인딩 되지 않은 토큰도 거부
+func WithCertificateBindingRequired() MiddlewareOption {
+	return func(m *JwtMiddleware) {
+		m.certificateBinding = true
+		m.certificateBindingRequired = true
+	}
 }
 
-func NewJwtMiddleware(extractor Extractor, validator *Validator[jwt.Claims], errorHandler ErrorHandler, claims jwt.Claims) *JwtMiddleware {
-	return &JwtMiddleware{
-		extractor: extractor,
-		validator: validator,
-		errorHandler: DefaultErrorHandler,
-		claims: claims,
+// NewJwtMiddleware: claims 는 요청마다 같은 타입의 새 인스턴스로 복제되어 사용됨
+// errorHandler 가 nil 인 경우 DefaultErrorHandler 사용
+func NewJwtMiddleware(extractor Extractor, validator TokenValidator[jwt.Claims], errorHandler ErrorHandler, claims jwt.Claims, opts ...MiddlewareOption) *JwtMiddleware {
+	if errorHandler == nil {
+		errorHandler = DefaultErrorHandler
 	}
+
+	m := &JwtMiddleware{
+		extractor:    extractor,
+		validator:    validator,
+		errorHandler: errorHandler,
+		claims:       claims,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 func (m *JwtMiddleware) CheckJwt(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// OPTIONS 요청은 통과 
-		if r.Method == http.MethodOptions {
-			next.ServeHTTP(w, r) 
-			return 
+		// OPTIONS 요청과 제외된 요청은 통과
+		if r.Method == http.MethodOptions || m.excluded(r) {
+			next.ServeHTTP(w, r)
+			return
 		}
 
-		tokenString, err := m.extractor(r)
-		if err != nil { 
-			m.errorHandler(w, r, err) 
-			return 
+		start := time.Now()
+		var span Span
+		authRequest := r
+		if m.tracer != nil {
+			var ctx context.Context
+			ctx, span = m.tracer.Start(r.Context(), SpanCheckJwt)
+			authRequest = r.WithContext(ctx)
 		}
 
-		if tokenString == "" {
-			m.errorHandler(w, r, ErrJwtMissing)
-			return 
+		tokenString, claims, tenant, err := m.authenticate(authRequest)
+		if span != nil {
+			setTokenSpanAttributes(span, tokenString, err)
+			span.End()
+		}
+		if m.metrics != nil {
+			alg := metricAlg(tokenString)
+			if err != nil {
+				m.metrics.TokenRejected(OperationMiddleware, alg, ErrorClass(err), time.Since(start))
+			} else {
+				m.metrics.TokenValidated(OperationMiddleware, alg, time.Since(start))
+			}
 		}
-
-		claims, err := m.validator.ValidateToken(tokenString, m.claims)
 		if err != nil {
+			if m.auditLogger != nil {
+				auditValidationFailed(m.auditLogger, r, tokenString, err)
+			}
 			m.errorHandler(w, r, err)
-			return 
+			return
 		}
 
-		r = r.Clone(context.WithValue(r.Context(), ContextKey{}, claims))
+		ctx := context.WithValue(r.Context(), ContextKey{}, contextClaims(claims))
+		if tenant != nil {
+			ctx = context.WithValue(ctx, TenantContextKey{}, tenant)
+		}
+
+		r = r.Clone(ctx)
 		next.ServeHTTP(w, r)
 	})
-}
\ No newline at end of file
+}
+
+func (m *JwtMiddleware) excluded(r *http.Request) bool {
+	for _, match := range m.exclusions {
+		if match(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// contextValidator: 요청 컨텍스트를 전달받아 트레이싱하는 검증기 (Validator[jwt.Claims])
+type contextValidator interface {
+	ValidateTokenContext(ctx context.Context, tokenString string, claims jwt.Claims) (jwt.Claims, error)
+}
+
+// authenticate: 요청에서 토큰을 추출하여 검증하고 DPoP, 인증서 바인딩 확인
+// 검증기가 TenantValidator 이면 검증에 사용한 테넌트도 반환
+func (m *JwtMiddleware) authenticate(r *http.Request) (string, jwt.Claims, *Tenant, error) {
+	tokenString, err := m.extractor(r)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if tokenString == "" {
+		return "", nil, nil, ErrJwtMissing
+	}
+
+	var claims jwt.Claims
+	var tenant *Tenant
+	switch validator := m.validator.(type) {
+	case contextValidator:
+		claims, err = validator.ValidateTokenContext(r.Context(), tokenString, newClaims(m.claims))
+	case TenantValidator:
+		tenant, claims, err = validator.ValidateTokenTenant(tokenString, newClaims(m.claims))
+	default:
+		claims, err = m.validator.ValidateToken(tokenString, newClaims(m.claims))
+	}
+	if err != nil {
+		return tokenString, nil, nil, err
+	}
+
+	if m.dpop != nil {
+		if err := m.checkDPoP(r, tokenString); err != nil {
+			return tokenString, nil, nil, err
+		}
+	}
+
+	if m.certificateBinding {
+		if err := m.checkCertificateBinding(r, tokenString); err != nil {
+			return tokenString, nil, nil, err
+		}
+	}
+
+	return tokenString, claims, tenant, nil
+}
+
+// checkDPoP: DPoP scheme 과 proof, 토큰의 cnf.jkt 바인딩 확인 (RFC 9449 Section 7.1)
+func (m *JwtMiddleware) checkDPoP(r *http.Request, tokenString string) error {
+	cnf, err := tokenConfirmation(tokenString)
+	if err != nil {
+		return err
+	}
+	var jkt string
+	if cnf != nil {
+		jkt = cnf.JKT
+	}
+
+	if authScheme(r) != "dpop" {
+		// 바인딩 된 토큰을 Bearer 로 전달하면 탈취된 토큰의 재사용으로 간주
+		if jkt != "" || m.dpopRequired {
+			return ErrDPoPProofMissing
+		}
+		return nil
+	}
+
+	thumbprint, err := m.dpop.ValidateProof(r, tokenString)
+	if err != nil {
+		return err
+	}
+	if jkt == "" || subtle.ConstantTimeCompare([]byte(jkt), []byte(thumbprint)) != 1 {
+		return ErrDPoPBindingMismatch
+	}
+	return nil
+}
+
+// checkCertificateBinding: 토큰의 cnf.x5t#S256 과 TLS 클라이언트 인증서 비교 (RFC 8705 Section 3)
+func (m *JwtMiddleware) checkCertificateBinding(r *http.Request, tokenString string) error {
+	cnf, err := tokenConfirmation(tokenString)
+	if err != nil {
+		return err
+	}
+	var x5t string
+	if cnf != nil {
+		x5t = cnf.X5TS256
+	}
+
+	if x5t == "" {
+		if m.certificateBindingRequired {
+			return ErrCertificateBindingMismatch
+		}
+		return nil
+	}
+
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return ErrCertificateBindingMismatch
+	}
+
+	thumbprint := CertificateThumbprint(r.TLS.PeerCertificates[0])
+	if subtle.ConstantTimeCompare([]byte(x5t), []byte(thumbprint)) != 1 {
+		return ErrCertificateBindingMismatch
+	}
+	return nil
+}
+
+// ClaimsFromContext: JwtMiddleware 가 저장한 클레임 조회
+func ClaimsFromContext[T jwt.Claims](ctx context.Context) (T, bool) {
+	claims, ok := ctx.Value(ContextKey{}).(T)
+	return claims, ok
+}
+
+// contextClaims: NewTokenMiddleware 로 만든 미들웨어는 감싸지 않은 token.Claims 를 컨텍스트에 저장
+func contextClaims(claims jwt.Claims) interface{} {
+	if c, ok := claims.(*tokenClaims); ok {
+		return c.claims
+	}
+	return claims
+}
+
+// newClaims: 동시 요청이 같은 클레임 인스턴스에 디코딩 하지 않도록 같은 타입의 빈 인스턴스 생성
+func newClaims(prototype jwt.Claims) jwt.Claims {
+	if prototype == nil {
+		return jwt.MapClaims{}
+	}
+
+	if c, ok := prototype.(*tokenClaims); ok {
+		return &tokenClaims{claims: token.NewClaims(c.claims)}
+	}
+
+	t := reflect.TypeOf(prototype)
+	switch t.Kind() {
+	case reflect.Pointer:
+		return reflect.New(t.Elem()).Interface().(jwt.Claims)
+	case reflect.Map:
+		return reflect.MakeMap(t).Interface().(jwt.Claims)
+	default:
+		return prototype
+	}
+}
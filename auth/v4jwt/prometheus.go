@@ -0,0 +1,159 @@
+package v4jwt
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets: 검증 소요 시간 히스토그램 기본 버킷 (초)
+var DefaultLatencyBuckets = []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1}
+
+// PrometheusMetrics: Prometheus text exposition 형식으로 노출하는 Metrics 구현체
+// 외부 의존성 없이 메모리에 집계하며 http.Handler 로 /metrics 에 연결
+//
+//	metrics := v4jwt.NewPrometheusMetrics()
+//	config := v4jwt.NewConfig(jwt.SigningMethodHS256, secret, v4jwt.WithMetrics(metrics))
+//	mux.Handle("/metrics", metrics)
+type PrometheusMetrics struct {
+	buckets []float64
+
+	mu         sync.Mutex
+	issued     map[string]uint64
+	validated  map[string]uint64
+	rejected   map[string]uint64
+	histograms map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewPrometheusMetrics: buckets 가 비어있으면 DefaultLatencyBuckets 사용
+func NewPrometheusMetrics(buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &PrometheusMetrics{
+		buckets:    buckets,
+		issued:     make(map[string]uint64),
+		validated:  make(map[string]uint64),
+		rejected:   make(map[string]uint64),
+		histograms: make(map[string]*histogram),
+	}
+}
+
+func (p *PrometheusMetrics) TokenIssued(alg string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.issued[labels("alg", alg)]++
+}
+
+func (p *PrometheusMetrics) TokenValidated(operation, alg string, duration time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.validated[labels("operation", operation, "alg", alg)]++
+	p.observe(labels("operation", operation, "outcome", "valid"), duration)
+}
+
+func (p *PrometheusMetrics) TokenRejected(operation, alg, reason string, duration time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rejected[labels("operation", operation, "alg", alg, "reason", reason)]++
+	p.observe(labels("operation", operation, "outcome", "rejected"), duration)
+}
+
+func (p *PrometheusMetrics) observe(key string, duration time.Duration) {
+	h, ok := p.histograms[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(p.buckets))}
+		p.histograms[key] = h
+	}
+
+	seconds := duration.Seconds()
+	for i, upper := range p.buckets {
+		if seconds <= upper {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// ServeHTTP: Prometheus text exposition format 0.0.4
+func (p *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = p.WriteTo(w)
+}
+
+// WriteTo: 현재 집계 값을 exposition 형식으로 기록
+func (p *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var b strings.Builder
+	writeCounter(&b, "jwt_tokens_issued_total", "Number of tokens issued.", p.issued)
+	writeCounter(&b, "jwt_tokens_validated_total", "Number of tokens validated successfully.", p.validated)
+	writeCounter(&b, "jwt_tokens_rejected_total", "Number of tokens rejected by reason.", p.rejected)
+
+	b.WriteString("# HELP jwt_validation_duration_seconds Token validation latency.\n")
+	b.WriteString("# TYPE jwt_validation_duration_seconds histogram\n")
+	for _, key := range sortedKeys(p.histograms) {
+		h := p.histograms[key]
+		for i, upper := range p.buckets {
+			fmt.Fprintf(&b, "jwt_validation_duration_seconds_bucket{%s,le=\"%s\"} %d\n", key, formatFloat(upper), h.counts[i])
+		}
+		fmt.Fprintf(&b, "jwt_validation_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", key, h.count)
+		fmt.Fprintf(&b, "jwt_validation_duration_seconds_sum{%s} %s\n", key, formatFloat(h.sum))
+		fmt.Fprintf(&b, "jwt_validation_duration_seconds_count{%s} %d\n", key, h.count)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func writeCounter(b *strings.Builder, name, help string, values map[string]uint64) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s counter\n", name)
+	for _, key := range sortedKeys(values) {
+		fmt.Fprintf(b, "%s{%s} %d\n", name, key, values[key])
+	}
+}
+
+// labels: name, value 쌍을 exposition 형식의 라벨 문자열로 변환
+func labels(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, pairs[i]+"=\""+escapeLabel(pairs[i+1])+"\"")
+	}
+	return strings.Join(parts, ",")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
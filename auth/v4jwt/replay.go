@@ -0,0 +1,97 @@
+package v4jwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	ErrTokenReplayed = errors.New("token has already been used")
+)
+
+// ReplayCache: 한 번만 허용되는 토큰의 jti 기록
+// 여러 인스턴스에서 공유해야 하는 경우 Redis 등으로 구현
+type ReplayCache interface {
+	// Use: jti 를 expiresAt 까지 기록, 이미 기록된 jti 면 false 반환
+	Use(ctx context.Context, jti string, expiresAt time.Time) (bool, error)
+}
+
+// minReplaySweepSize: 만료된 jti 를 정리하기 시작하는 기록 수
+const minReplaySweepSize = 1024
+
+// MemoryReplayCache: 메모리 기반 ReplayCache 구현체
+// 만료된 jti 는 기록 수가 마지막 정리 후 남은 수의 두 배가 될 때 정리하여 Use 의 비용은 분할 상환 O(1)
+type MemoryReplayCache struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+	now  func() time.Time
+	// sweepSize: 만료된 jti 를 정리할 기록 수
+	sweepSize int
+}
+
+func NewMemoryReplayCache() *MemoryReplayCache {
+	return &MemoryReplayCache{
+		seen:      make(map[string]time.Time),
+		now:       time.Now,
+		sweepSize: minReplaySweepSize,
+	}
+}
+
+func (c *MemoryReplayCache) Use(_ context.Context, jti string, expiresAt time.Time) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 정리되지 않은 만료된 jti 는 기록이 없는 것으로 간주
+	now := c.now()
+	if exp, ok := c.seen[jti]; ok && now.Before(exp) {
+		return false, nil
+	}
+	c.seen[jti] = expiresAt
+
+	if len(c.seen) >= c.sweepSize {
+		c.sweep(now)
+	}
+	return true, nil
+}
+
+// sweep: 만료된 jti 를 삭제하고 다음 정리 시점 설정
+func (c *MemoryReplayCache) sweep(now time.Time) {
+	for k, exp := range c.seen {
+		if !now.Before(exp) {
+			delete(c.seen, k)
+		}
+	}
+	c.sweepSize = max(2*len(c.seen), minReplaySweepSize)
+}
+
+// WithReplayCache: 검증에 성공한 토큰의 jti 를 기록하고 재사용을 ErrTokenReplayed 로 거부
+// jti, exp 클레임이 없는 토큰은 거부
+func WithReplayCache(cache ReplayCache) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.replayCache = cache
+	}
+}
+
+// checkReplay: 서명 검증이 끝난 토큰의 jti 를 exp 까지 기록
+func (v *Validator[T]) checkReplay(claims *jwt.RegisteredClaims) error {
+	if claims.ID == "" {
+		return fmt.Errorf("%w: jti is required", ErrTokenInvalidId)
+	}
+	if claims.ExpiresAt == nil {
+		return fmt.Errorf("%w: exp is required", ErrTokenInvalidClaims)
+	}
+
+	ok, err := v.replayCache.Use(context.Background(), claims.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTokenReplayed
+	}
+	return nil
+}
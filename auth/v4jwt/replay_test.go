@@ -0,0 +1,130 @@
+package v4jwt
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryReplayCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cache := NewMemoryReplayCache()
+	cache.now = func() time.Time { return now }
+
+	ok, err := cache.Use(ctx, "jti-1", now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	t.Run("만료 전 재사용 거부", func(t *testing.T) {
+		ok, err := cache.Use(ctx, "jti-1", now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("다른 jti 는 허용", func(t *testing.T) {
+		ok, err := cache.Use(ctx, "jti-2", now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("만료 후 재사용 허용", func(t *testing.T) {
+		cache.now = func() time.Time { return now.Add(2 * time.Minute) }
+		ok, err := cache.Use(ctx, "jti-1", now.Add(3*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
+
+func TestMemoryReplayCacheSweep(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	cache := NewMemoryReplayCache()
+	cache.now = func() time.Time { return now }
+
+	// 정리 시점 전까지는 만료된 jti 도 남아있음
+	for i := 0; i < minReplaySweepSize-1; i++ {
+		ok, err := cache.Use(ctx, fmt.Sprintf("expired-%d", i), now.Add(time.Second))
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	cache.now = func() time.Time { return now.Add(time.Minute) }
+	assert.Len(t, cache.seen, minReplaySweepSize-1)
+
+	ok, err := cache.Use(ctx, "jti-1", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, cache.seen, 1)
+	assert.Equal(t, minReplaySweepSize, cache.sweepSize)
+
+	// 만료되지 않은 jti 가 많으면 정리 간격도 늘어남
+	for i := 0; i < minReplaySweepSize; i++ {
+		_, err := cache.Use(ctx, fmt.Sprintf("live-%d", i), now.Add(time.Hour))
+		require.NoError(t, err)
+	}
+	assert.Len(t, cache.seen, minReplaySweepSize+1)
+	assert.Equal(t, 2*minReplaySweepSize, cache.sweepSize)
+}
+
+func TestValidatorReplayCache(t *testing.T) {
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"))
+	creator := NewCreator(config)
+	validator := NewValidator[*validateTestClaims](config, WithReplayCache(NewMemoryReplayCache()))
+
+	newToken := func(jti string, exp *jwt.NumericDate) string {
+		token, err := creator.CreateToken(&validateTestClaims{
+			UserId:           "123",
+			RegisteredClaims: jwt.RegisteredClaims{ID: jti, ExpiresAt: exp},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	exp := jwt.NewNumericDate(time.Now().Add(time.Minute))
+
+	t.Run("한 번만 허용", func(t *testing.T) {
+		token := newToken("reset-1", exp)
+		_, err := validator.ValidateToken(token, &validateTestClaims{})
+		require.NoError(t, err)
+
+		_, err = validator.ValidateToken(token, &validateTestClaims{})
+		assert.ErrorIs(t, err, ErrTokenReplayed)
+	})
+
+	t.Run("jti 가 없는 토큰 거부", func(t *testing.T) {
+		_, err := validator.ValidateToken(newToken("", exp), &validateTestClaims{})
+		assert.ErrorIs(t, err, ErrTokenInvalidId)
+	})
+
+	t.Run("exp 가 없는 토큰 거부", func(t *testing.T) {
+		_, err := validator.ValidateToken(newToken("reset-2", nil), &validateTestClaims{})
+		assert.ErrorIs(t, err, ErrTokenInvalidClaims)
+	})
+
+	t.Run("서명이 잘못된 토큰은 기록하지 않음", func(t *testing.T) {
+		other := NewCreator(NewConfig(jwt.SigningMethodHS256, []byte("other")))
+		forged, err := other.CreateToken(&validateTestClaims{
+			UserId:           "123",
+			RegisteredClaims: jwt.RegisteredClaims{ID: "reset-3", ExpiresAt: exp},
+		})
+		require.NoError(t, err)
+		_, err = validator.ValidateToken(forged, &validateTestClaims{})
+		assert.ErrorIs(t, err, ErrTokenSignatureInvalid)
+
+		_, err = validator.ValidateToken(newToken("reset-3", exp), &validateTestClaims{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("DefaultErrorHandler 는 401 응답", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		DefaultErrorHandler(w, httptest.NewRequest(http.MethodGet, "/", nil), ErrTokenReplayed)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "token has already been used")
+	})
+}
@@ -0,0 +1,85 @@
+package v4jwt
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// minSecretSize: HMAC secret 최소 길이 (RFC 7518 Section 3.2, HS256 의 해시 크기)
+const minSecretSize = 32
+
+var (
+	ErrSecretNotFound = errors.New("secret not found")
+	ErrSecretTooShort = errors.New("secret is too short")
+)
+
+// Secret: SecretProvider 가 반환한 HMAC secret
+type Secret struct {
+	Value []byte
+	// Version: secret 이 바뀌면 달라지는 값, SecretKeyRing 은 이 값을 kid 로 사용
+	Version string
+	// LeaseDuration: secret 을 사용할 수 있는 기간, 0 이면 기간 제한 없음
+	LeaseDuration time.Duration
+}
+
+// SecretProvider: 환경 변수, 파일, Vault 등 외부 저장소에서 HMAC secret 조회
+type SecretProvider interface {
+	Secret(ctx context.Context) (*Secret, error)
+}
+
+// EnvSecretProvider: 환경 변수의 값을 secret 으로 사용
+// 버전은 값의 해시이므로 프로세스가 환경 변수를 바꾸면 교체로 처리
+type EnvSecretProvider struct {
+	name string
+}
+
+func NewEnvSecretProvider(name string) *EnvSecretProvider {
+	return &EnvSecretProvider{name: name}
+}
+
+func (p *EnvSecretProvider) Secret(ctx context.Context) (*Secret, error) {
+	value, ok := os.LookupEnv(p.name)
+	if !ok || value == "" {
+		return nil, fmt.Errorf("%w: environment variable %s", ErrSecretNotFound, p.name)
+	}
+	return newSecret([]byte(value), "")
+}
+
+// FileSecretProvider: 파일 내용을 secret 으로 사용, 끝의 줄바꿈은 제거
+// Kubernetes Secret 볼륨처럼 파일이 교체되는 경우 버전 (내용의 해시) 이 바뀜
+type FileSecretProvider struct {
+	path string
+}
+
+func NewFileSecretProvider(path string) *FileSecretProvider {
+	return &FileSecretProvider{path: path}
+}
+
+func (p *FileSecretProvider) Secret(ctx context.Context) (*Secret, error) {
+	b, err := os.ReadFile(p.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %v", ErrSecretNotFound, err)
+		}
+		return nil, err
+	}
+	return newSecret(bytes.TrimRight(b, "\r\n"), "")
+}
+
+// newSecret: 길이를 확인하고, version 이 비어있으면 값의 해시를 버전으로 사용
+func newSecret(value []byte, version string) (*Secret, error) {
+	if len(value) < minSecretSize {
+		return nil, fmt.Errorf("%w: HMAC secret must be at least %d bytes, got %d", ErrSecretTooShort, minSecretSize, len(value))
+	}
+	if version == "" {
+		sum := sha256.Sum256(value)
+		version = base64.RawURLEncoding.EncodeToString(sum[:8])
+	}
+	return &Secret{Value: value, Version: version}, nil
+}
@@ -0,0 +1,188 @@
+package v4jwt
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testVaultToken = "vault-token"
+
+// fakeVaultKV: Vault KV v2 의 GET /v1/<mount>/data/<path> 만 흉내내는 서버
+type fakeVaultKV struct {
+	mu            sync.Mutex
+	secrets       map[string]string
+	version       int
+	leaseDuration int
+	requests      int
+}
+
+func newFakeVaultKV(t *testing.T, secret string) (*fakeVaultKV, *httptest.Server) {
+	kv := &fakeVaultKV{}
+	kv.put(map[string]string{"secret": secret})
+	server := httptest.NewServer(kv)
+	t.Cleanup(server.Close)
+	return kv, server
+}
+
+// put: 새 버전 저장
+func (kv *fakeVaultKV) put(secrets map[string]string) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.secrets = secrets
+	kv.version++
+}
+
+func (kv *fakeVaultKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.requests++
+
+	if r.Header.Get("X-Vault-Token") != testVaultToken {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodGet || r.URL.Path != "/v1/secret/data/jwt" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	body := map[string]interface{}{
+		"lease_duration": kv.leaseDuration,
+		"data": map[string]interface{}{
+			"data": kv.secrets,
+			"metadata": map[string]interface{}{
+				"created_time": time.Now().UTC().Format(time.RFC3339Nano),
+				"version":      kv.version,
+			},
+		},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestVaultKVProvider(t *testing.T) {
+	secret := strings.Repeat("a", 32)
+	kv, server := newFakeVaultKV(t, secret)
+
+	testCases := []struct {
+		name            string
+		path            string
+		opts            []VaultOption
+		expectedVersion string
+		expectedError   error
+		expectedMessage string
+	}{
+		{
+			name:            "정상 조회",
+			path:            "jwt",
+			opts:            []VaultOption{WithVaultToken(testVaultToken)},
+			expectedVersion: "1",
+		},
+		{
+			name:            "토큰 없음",
+			path:            "jwt",
+			expectedMessage: "unexpected status 403",
+		},
+		{
+			name:          "없는 경로",
+			path:          "missing",
+			opts:          []VaultOption{WithVaultToken(testVaultToken)},
+			expectedError: ErrSecretNotFound,
+		},
+		{
+			name:          "없는 필드",
+			path:          "jwt",
+			opts:          []VaultOption{WithVaultToken(testVaultToken), WithVaultField("hmac")},
+			expectedError: ErrSecretNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := NewVaultKVProvider(server.URL+"/", "secret", tc.path, tc.opts...)
+			got, err := provider.Secret(context.Background())
+			switch {
+			case tc.expectedError != nil:
+				assert.ErrorIs(t, err, tc.expectedError)
+			case tc.expectedMessage != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedMessage)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, []byte(secret), got.Value)
+				assert.Equal(t, tc.expectedVersion, got.Version)
+				assert.Zero(t, got.LeaseDuration)
+			}
+		})
+	}
+
+	t.Run("짧은 secret 거부", func(t *testing.T) {
+		kv.put(map[string]string{"secret": "short"})
+		_, err := NewVaultKVProvider(server.URL, "secret", "jwt", WithVaultToken(testVaultToken)).Secret(context.Background())
+		assert.ErrorIs(t, err, ErrSecretTooShort)
+	})
+
+	t.Run("임대 기간", func(t *testing.T) {
+		kv.put(map[string]string{"secret": secret})
+		kv.mu.Lock()
+		kv.leaseDuration = 60
+		kv.mu.Unlock()
+		got, err := NewVaultKVProvider(server.URL, "secret", "jwt", WithVaultToken(testVaultToken)).Secret(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, got.LeaseDuration)
+	})
+}
+
+func TestEnvSecretProvider(t *testing.T) {
+	secret := strings.Repeat("e", 32)
+	t.Setenv("V4JWT_TEST_SECRET", secret)
+
+	provider := NewEnvSecretProvider("V4JWT_TEST_SECRET")
+	got, err := provider.Secret(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(secret), got.Value)
+	assert.NotEmpty(t, got.Version)
+
+	// 값이 바뀌면 버전도 바뀜
+	t.Setenv("V4JWT_TEST_SECRET", strings.Repeat("f", 32))
+	rotated, err := provider.Secret(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, got.Version, rotated.Version)
+
+	_, err = NewEnvSecretProvider("V4JWT_TEST_SECRET_MISSING").Secret(context.Background())
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+func TestFileSecretProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	secret := strings.Repeat("s", 32)
+	require.NoError(t, os.WriteFile(path, []byte(secret+"\n"), 0o600))
+
+	provider := NewFileSecretProvider(path)
+	got, err := provider.Secret(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []byte(secret), got.Value)
+
+	// 같은 내용이면 버전 유지
+	again, err := provider.Secret(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, got.Version, again.Version)
+
+	require.NoError(t, os.WriteFile(path, []byte("short"), 0o600))
+	_, err = provider.Secret(context.Background())
+	assert.ErrorIs(t, err, ErrSecretTooShort)
+
+	_, err = NewFileSecretProvider(filepath.Join(t.TempDir(), "missing")).Secret(context.Background())
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
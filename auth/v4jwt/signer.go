@@ -0,0 +1,135 @@
+package v4jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var ErrSignerMismatch = errors.New("signer key does not match the signing method")
+
+// SignerMethod: crypto.Signer 로 서명하는 SigningMethod
+// KMS, HSM, PKCS #11 토큰처럼 개인키를 프로세스 메모리에 두지 않는 서명자에 사용
+// alg 은 감싼 SigningMethod 와 같으므로 검증은 표준 SigningMethod 로 가능
+type SignerMethod struct {
+	jwt.SigningMethod
+	hash crypto.Hash
+	opts crypto.SignerOpts
+	// ECDSA 서명의 r, s 바이트 크기, ECDSA 가 아니면 0
+	keySize int
+}
+
+// NewSignerMethod: RS*, PS*, ES*, EdDSA SigningMethod 를 crypto.Signer 용으로 감쌈
+func NewSignerMethod(method jwt.SigningMethod) (*SignerMethod, error) {
+	switch m := method.(type) {
+	case *jwt.SigningMethodRSA:
+		return &SignerMethod{SigningMethod: m, hash: m.Hash, opts: m.Hash}, nil
+	case *jwt.SigningMethodRSAPSS:
+		return &SignerMethod{SigningMethod: m, hash: m.Hash, opts: &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       m.Hash,
+		}}, nil
+	case *jwt.SigningMethodECDSA:
+		return &SignerMethod{SigningMethod: m, hash: m.Hash, opts: m.Hash, keySize: m.KeySize}, nil
+	case *jwt.SigningMethodEd25519:
+		// Ed25519 는 메시지를 직접 서명
+		return &SignerMethod{SigningMethod: m, opts: crypto.Hash(0)}, nil
+	default:
+		return nil, fmt.Errorf("%w: alg %s", ErrUnsupportedKey, method.Alg())
+	}
+}
+
+// Sign: key 는 crypto.Signer 여야 하며, 서명 결과는 JWS 형식 (ECDSA 는 r || s) 으로 변환
+func (m *SignerMethod) Sign(signingString string, key interface{}) (string, error) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return "", ErrInvalidKeyType
+	}
+	if err := m.checkPublicKey(signer.Public()); err != nil {
+		return "", err
+	}
+
+	digest := []byte(signingString)
+	if m.hash != 0 {
+		if !m.hash.Available() {
+			return "", ErrHashUnavailable
+		}
+		hasher := m.hash.New()
+		hasher.Write(digest)
+		digest = hasher.Sum(nil)
+	}
+
+	signature, err := signer.Sign(rand.Reader, digest, m.opts)
+	if err != nil {
+		return "", err
+	}
+
+	if m.keySize > 0 {
+		if signature, err = ecdsaSignature(signature, m.keySize); err != nil {
+			return "", err
+		}
+	}
+	return jwt.EncodeSegment(signature), nil
+}
+
+// checkPublicKey: 서명자의 공개키가 알고리즘에 맞는지 확인 (ES256 에 P-384 키 등)
+func (m *SignerMethod) checkPublicKey(public crypto.PublicKey) error {
+	switch m.SigningMethod.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		if _, ok := public.(*rsa.PublicKey); !ok {
+			return ErrSignerMismatch
+		}
+	case *jwt.SigningMethodECDSA:
+		key, ok := public.(*ecdsa.PublicKey)
+		if !ok || (key.Curve.Params().BitSize+7)/8 != m.keySize {
+			return ErrSignerMismatch
+		}
+	case *jwt.SigningMethodEd25519:
+		if _, ok := public.(ed25519.PublicKey); !ok {
+			return ErrSignerMismatch
+		}
+	}
+	return nil
+}
+
+// ecdsaSignature: crypto.Signer 의 ASN.1 DER 서명을 JWS 의 고정 길이 r || s 로 변환 (RFC 7518 Section 3.4)
+func ecdsaSignature(der []byte, keySize int) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ECDSA signature from signer: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("invalid ECDSA signature from signer: trailing data")
+	}
+	if sig.R.BitLen() > keySize*8 || sig.S.BitLen() > keySize*8 {
+		return nil, ErrSignerMismatch
+	}
+
+	out := make([]byte, 2*keySize)
+	sig.R.FillBytes(out[:keySize])
+	sig.S.FillBytes(out[keySize:])
+	return out, nil
+}
+
+// NewSignerConfig: crypto.Signer 로 서명하는 설정, 검증키는 signer.Public()
+func NewSignerConfig(method jwt.SigningMethod, signer crypto.Signer, opts ...ConfigOption) (*Config, error) {
+	signerMethod, err := NewSignerMethod(method)
+	if err != nil {
+		return nil, err
+	}
+	if err := signerMethod.checkPublicKey(signer.Public()); err != nil {
+		return nil, err
+	}
+	return NewKeyPairConfig(signerMethod, signer, signer.Public(), opts...), nil
+}
@@ -0,0 +1,93 @@
+package v4jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// opaqueSigner: 개인키 타입을 드러내지 않는 crypto.Signer (KMS, HSM 클라이언트 대역)
+type opaqueSigner struct {
+	signer crypto.Signer
+	calls  int
+}
+
+func (s *opaqueSigner) Public() crypto.PublicKey {
+	return s.signer.Public()
+}
+
+func (s *opaqueSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	s.calls++
+	return s.signer.Sign(rand, digest, opts)
+}
+
+func TestSignerMethod(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p521, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		method jwt.SigningMethod
+		key    crypto.Signer
+	}{
+		{method: jwt.SigningMethodRS256, key: rsaKey},
+		{method: jwt.SigningMethodRS512, key: rsaKey},
+		{method: jwt.SigningMethodPS256, key: rsaKey},
+		{method: jwt.SigningMethodES256, key: p256},
+		{method: jwt.SigningMethodES384, key: p384},
+		{method: jwt.SigningMethodES512, key: p521},
+		{method: jwt.SigningMethodEdDSA, key: edKey},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.method.Alg(), func(t *testing.T) {
+			t.Parallel()
+			signer := &opaqueSigner{signer: tc.key}
+			config, err := NewSignerConfig(tc.method, signer, WithKeyID("kms-key"))
+			require.NoError(t, err)
+
+			token, err := NewCreator(config).CreateToken(&jwt.RegisteredClaims{
+				Subject:   "user-1",
+				ExpiresAt: jwt.NewNumericDate(jwt.TimeFunc().Add(time.Minute)),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, signer.calls)
+
+			// 표준 SigningMethod 와 공개키만 가진 Validator 로 검증 가능해야 함
+			validator := NewValidator[*jwt.RegisteredClaims](NewKeyPairConfig(tc.method, nil, tc.key.Public()))
+			claims, err := validator.ValidateToken(token, &jwt.RegisteredClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", claims.Subject)
+		})
+	}
+
+	t.Run("알고리즘과 맞지 않는 서명자", func(t *testing.T) {
+		_, err := NewSignerConfig(jwt.SigningMethodES256, &opaqueSigner{signer: p384})
+		assert.ErrorIs(t, err, ErrSignerMismatch)
+		_, err = NewSignerConfig(jwt.SigningMethodRS256, &opaqueSigner{signer: edKey})
+		assert.ErrorIs(t, err, ErrSignerMismatch)
+	})
+
+	t.Run("HMAC 은 지원하지 않음", func(t *testing.T) {
+		_, err := NewSignerMethod(jwt.SigningMethodHS256)
+		assert.ErrorIs(t, err, ErrUnsupportedKey)
+	})
+}
@@ -0,0 +1,110 @@
+package v4jwt
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	ErrUnknownIssuer = errors.New("unknown token issuer")
+)
+
+type TenantContextKey struct{}
+
+// Tenant: 발급자 (issuer) 별 검증 설정
+type Tenant struct {
+	ID     string
+	Issuer string
+	// Validator: 테넌트의 키, audience, 클레임 규칙으로 설정한 검증기
+	Validator TokenValidator[jwt.Claims]
+	// KeyIDs: 허용하는 kid 목록, 비어있으면 확인하지 않음
+	KeyIDs []string
+}
+
+// TenantResolver: 토큰의 테넌트를 조회하는 검증기
+type TenantResolver interface {
+	ResolveTenant(tokenString string) (*Tenant, error)
+}
+
+// TenantValidator: 검증에 사용한 테넌트를 함께 반환하는 검증기
+// JwtMiddleware 는 검증기가 TenantValidator 를 구현하면 테넌트를 컨텍스트에 저장
+type TenantValidator interface {
+	ValidateTokenTenant(tokenString string, claims jwt.Claims) (*Tenant, jwt.Claims, error)
+}
+
+// MultiIssuerValidator: 검증 전의 iss (와 kid) 로 테넌트를 찾아 해당 Validator 로 검증
+// 등록되지 않은 issuer 는 서명 검증 없이 거부
+type MultiIssuerValidator struct {
+	tenants map[string]*Tenant
+	parser  *jwt.Parser
+}
+
+func NewMultiIssuerValidator(tenants ...*Tenant) *MultiIssuerValidator {
+	v := &MultiIssuerValidator{
+		tenants: make(map[string]*Tenant, len(tenants)),
+		parser:  jwt.NewParser(),
+	}
+	for _, tenant := range tenants {
+		v.tenants[tenant.Issuer] = tenant
+	}
+	return v
+}
+
+// ResolveTenant: 서명을 검증하지 않고 iss, kid 만 확인하여 테넌트 조회
+func (v *MultiIssuerValidator) ResolveTenant(tokenString string) (*Tenant, error) {
+	claims := &jwt.RegisteredClaims{}
+	token, _, err := v.parser.ParseUnverified(tokenString, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, ok := v.tenants[claims.Issuer]
+	if !ok {
+		return nil, ErrUnknownIssuer
+	}
+
+	if len(tenant.KeyIDs) > 0 {
+		kid, _ := token.Header["kid"].(string)
+		if !containsString(tenant.KeyIDs, kid) {
+			return nil, ErrKeyNotFound
+		}
+	}
+	return tenant, nil
+}
+
+func (v *MultiIssuerValidator) ValidateToken(tokenString string, claims jwt.Claims) (jwt.Claims, error) {
+	_, claims, err := v.ValidateTokenTenant(tokenString, claims)
+	return claims, err
+}
+
+// ValidateTokenTenant: 토큰을 한 번만 파싱하여 검증하고 검증에 사용한 테넌트 반환
+func (v *MultiIssuerValidator) ValidateTokenTenant(tokenString string, claims jwt.Claims) (*Tenant, jwt.Claims, error) {
+	tenant, err := v.ResolveTenant(tokenString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 라우팅에 사용한 iss 는 테넌트 Validator 의 서명 검증으로 함께 확인됨
+	claims, err = tenant.Validator.ValidateToken(tokenString, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tenant, claims, nil
+}
+
+// TenantFromContext: JwtMiddleware 가 저장한 테넌트 조회
+func TenantFromContext(ctx context.Context) (*Tenant, bool) {
+	tenant, ok := ctx.Value(TenantContextKey{}).(*Tenant)
+	return tenant, ok
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
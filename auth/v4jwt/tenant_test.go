@@ -0,0 +1,127 @@
+package v4jwt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingValidator: 테넌트 Validator 호출 여부 확인용
+type countingValidator struct {
+	TokenValidator[jwt.Claims]
+	calls int
+}
+
+func (v *countingValidator) ValidateToken(tokenString string, claims jwt.Claims) (jwt.Claims, error) {
+	v.calls++
+	return v.TokenValidator.ValidateToken(tokenString, claims)
+}
+
+func TestMultiIssuerValidator(t *testing.T) {
+	configA := NewConfig(jwt.SigningMethodHS256, []byte("secret-a"), WithKeyID("a-1"))
+	configB := NewConfig(jwt.SigningMethodHS256, []byte("secret-b"), WithKeyID("b-1"))
+
+	validatorA := &countingValidator{TokenValidator: NewValidator[jwt.Claims](configA, WithAudience("api"))}
+	validatorB := &countingValidator{TokenValidator: NewValidator[jwt.Claims](configB)}
+	tenantA := &Tenant{ID: "tenant-a", Issuer: "https://a.example.com", Validator: validatorA, KeyIDs: []string{"a-1"}}
+	tenantB := &Tenant{ID: "tenant-b", Issuer: "https://b.example.com", Validator: validatorB}
+	validator := NewMultiIssuerValidator(tenantA, tenantB)
+
+	newToken := func(config *Config, issuer string) string {
+		token, err := NewCreator(config).CreateToken(&jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   "user-1",
+			Audience:  jwt.ClaimStrings{"api"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	testCases := []struct {
+		name           string
+		token          string
+		expectedTenant *Tenant
+		expectedError  error
+	}{
+		{
+			name:           "tenant-a 토큰",
+			token:          newToken(configA, tenantA.Issuer),
+			expectedTenant: tenantA,
+		},
+		{
+			name:           "tenant-b 토큰",
+			token:          newToken(configB, tenantB.Issuer),
+			expectedTenant: tenantB,
+		},
+		{
+			name:          "허용되지 않은 kid",
+			token:         newToken(configB, tenantA.Issuer),
+			expectedError: ErrKeyNotFound,
+		},
+		{
+			name:          "다른 테넌트의 키로 서명",
+			token:         newToken(configA, tenantB.Issuer),
+			expectedError: ErrTokenSignatureInvalid,
+		},
+		{
+			name:          "잘못된 형식의 토큰",
+			token:         "invalid",
+			expectedError: ErrTokenMalformed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tenant, claims, err := validator.ValidateTokenTenant(tc.token, &jwt.RegisteredClaims{})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, tenant)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTenant, tenant)
+			assert.Equal(t, tc.expectedTenant.Issuer, claims.(*jwt.RegisteredClaims).Issuer)
+
+			resolved, err := validator.ResolveTenant(tc.token)
+			require.NoError(t, err)
+			assert.Equal(t, tenant, resolved)
+		})
+	}
+
+	t.Run("등록되지 않은 issuer 는 서명 검증 전에 거부", func(t *testing.T) {
+		callsA, callsB := validatorA.calls, validatorB.calls
+
+		_, err := validator.ValidateToken(newToken(configA, "https://unknown.example.com"), &jwt.RegisteredClaims{})
+		assert.ErrorIs(t, err, ErrUnknownIssuer)
+		assert.Equal(t, callsA, validatorA.calls)
+		assert.Equal(t, callsB, validatorB.calls)
+	})
+
+	t.Run("JwtMiddleware 컨텍스트에 테넌트 저장", func(t *testing.T) {
+		m := NewJwtMiddleware(AuthHeaderExtractor, validator, nil, &jwt.RegisteredClaims{})
+		h := m.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenant, ok := TenantFromContext(r.Context())
+			require.True(t, ok)
+			_, _ = w.Write([]byte(tenant.ID))
+		}))
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+newToken(configB, tenantB.Issuer))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "tenant-b", w.Body.String())
+
+		r = httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+newToken(configA, "https://unknown.example.com"))
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,37 @@
+package v4jwt
+
+import (
+	"errors"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+// 토큰 헤더의 typ 값 (RFC 8725 Section 3.11)
+const (
+	// AccessTokenType: JWT access 토큰 (RFC 9068 Section 2.1)
+	AccessTokenType = "at+jwt"
+	// RefreshTokenType: refresh 토큰, 표준 값이 없어 access 토큰과 구분하기 위해 사용
+	RefreshTokenType = "rt+jwt"
+	// IDTokenType: OpenID Connect ID 토큰은 typ 을 정의하지 않아 일반적으로 JWT 사용
+	IDTokenType = "JWT"
+)
+
+var (
+	ErrTokenTypeMismatch = token.ErrTokenTypeMismatch
+	// ErrTokenTypeUnsupported: TokenManager 의 Creator 가 TypedTokenCreator 가 아닌 경우
+	ErrTokenTypeUnsupported = errors.New("token creator does not support token types")
+)
+
+// TypedTokenCreator: 헤더의 typ 을 지정하여 토큰을 생성하는 Creator (Creator, TokenManager)
+type TypedTokenCreator interface {
+	CreateTypedToken(claims jwt.Claims, typ string) (string, error)
+}
+
+// WithExpectedTypes: 헤더의 typ 이 types 중 하나인 토큰만 허용
+// 같은 키로 서명한 refresh, ID 토큰을 access 토큰으로 사용하는 token confusion 방지
+func WithExpectedTypes(types ...string) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.expectedTypes = types
+	}
+}
@@ -0,0 +1,73 @@
+package v4jwt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenType(t *testing.T) {
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"))
+	claims := &jwt.RegisteredClaims{
+		Subject:   "user-1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}
+
+	accessToken, err := NewCreator(config, WithTokenType(AccessTokenType)).CreateToken(claims)
+	require.NoError(t, err)
+	refreshToken, err := NewCreator(config).CreateTypedToken(claims, RefreshTokenType)
+	require.NoError(t, err)
+	untypedToken, err := NewCreator(config).CreateToken(claims)
+	require.NoError(t, err)
+
+	token, _, err := jwt.NewParser().ParseUnverified(accessToken, &jwt.RegisteredClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, AccessTokenType, token.Header["typ"])
+
+	validator := NewValidator[*jwt.RegisteredClaims](config, WithExpectedTypes(AccessTokenType))
+
+	testCases := []struct {
+		name          string
+		token         string
+		expectedError error
+	}{
+		{name: "access 토큰 허용", token: accessToken},
+		{name: "refresh 토큰 거부", token: refreshToken, expectedError: ErrTokenTypeMismatch},
+		{name: "typ 이 없는 토큰 거부", token: untypedToken, expectedError: ErrTokenTypeMismatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validator.ValidateToken(tc.token, &jwt.RegisteredClaims{})
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+
+	t.Run("application/ 접두어와 대소문자 무시", func(t *testing.T) {
+		token, err := NewCreator(config, WithTokenType("application/AT+JWT")).CreateToken(claims)
+		require.NoError(t, err)
+		_, err = validator.ValidateToken(token, &jwt.RegisteredClaims{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("기대하는 타입이 없으면 typ 을 확인하지 않음", func(t *testing.T) {
+		_, err := NewValidator[*jwt.RegisteredClaims](config).ValidateToken(refreshToken, &jwt.RegisteredClaims{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("DefaultErrorHandler 는 401 응답", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		DefaultErrorHandler(w, httptest.NewRequest(http.MethodGet, "/", nil), ErrTokenTypeMismatch)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid token type")
+	})
+}
@@ -0,0 +1,150 @@
+package v4jwt
+
+import (
+	"context"
+	"sync"
+)
+
+// span 이름
+const (
+	SpanCheckJwt      = "jwt.CheckJwt"
+	SpanValidateToken = "jwt.ValidateToken"
+)
+
+// span 속성 키
+const (
+	AttributeAlg        = "jwt.alg"
+	AttributeKeyID      = "jwt.kid"
+	AttributeIssuer     = "jwt.issuer"
+	AttributeOutcome    = "jwt.outcome"
+	AttributeErrorClass = "jwt.error_class"
+	// AttributeSubject: 인증된 사용자 (OpenTelemetry semantic conventions 의 enduser.id)
+	AttributeSubject = "enduser.id"
+)
+
+// Tracer: span 생성 인터페이스, OpenTelemetry 의 trace.Tracer 를 감싸서 구현
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, v4jwt.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span: 종료 전까지 속성과 에러를 기록하는 span
+type Span interface {
+	SetAttribute(key, value string)
+	RecordError(err error)
+	End()
+}
+
+// WithTracer: 이 설정을 사용하는 Validator 의 ValidateToken 을 트레이싱
+func WithTracer(tracer Tracer) ConfigOption {
+	return func(c *Config) {
+		c.tracer = tracer
+	}
+}
+
+// WithRequestTracer: JwtMiddleware 의 CheckJwt 를 트레이싱
+// Validator 에도 Tracer 가 설정되어 있으면 ValidateToken span 은 CheckJwt span 의 자식이 됨
+func WithRequestTracer(tracer Tracer) MiddlewareOption {
+	return func(m *JwtMiddleware) {
+		m.tracer = tracer
+	}
+}
+
+// setTokenSpanAttributes: 토큰 헤더, issuer 와 검증 결과를 span 에 기록
+// 실패한 토큰의 alg, kid, issuer 는 서명 검증 전의 값이며, subject 는 성공한 경우에만 기록
+func setTokenSpanAttributes(span Span, tokenString string, err error) {
+	header := peekHeader(tokenString)
+	if header.Alg != "" {
+		span.SetAttribute(AttributeAlg, header.Alg)
+	}
+	if header.Kid != "" {
+		span.SetAttribute(AttributeKeyID, header.Kid)
+	}
+
+	claims, _ := registeredClaimsFromString(tokenString)
+	if claims != nil && claims.Issuer != "" {
+		span.SetAttribute(AttributeIssuer, claims.Issuer)
+	}
+
+	if err != nil {
+		span.SetAttribute(AttributeOutcome, "rejected")
+		span.SetAttribute(AttributeErrorClass, ErrorClass(err))
+		span.RecordError(err)
+		return
+	}
+
+	span.SetAttribute(AttributeOutcome, "valid")
+	if claims != nil && claims.Subject != "" {
+		span.SetAttribute(AttributeSubject, claims.Subject)
+	}
+}
+
+// SpanRecorder: 메모리에 span 을 기록하는 Tracer, 테스트용
+type SpanRecorder struct {
+	mu    sync.Mutex
+	spans []*RecordedSpan
+}
+
+// RecordedSpan: SpanRecorder 가 기록한 span
+type RecordedSpan struct {
+	Name       string
+	Parent     *RecordedSpan
+	Attributes map[string]string
+	Errors     []error
+	Ended      bool
+
+	recorder *SpanRecorder
+}
+
+type recordedSpanKey struct{}
+
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+func (r *SpanRecorder) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(recordedSpanKey{}).(*RecordedSpan)
+	span := &RecordedSpan{
+		Name:       name,
+		Parent:     parent,
+		Attributes: make(map[string]string),
+		recorder:   r,
+	}
+
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+
+	return context.WithValue(ctx, recordedSpanKey{}, span), span
+}
+
+// Spans: 시작된 순서대로 기록된 span 반환
+func (r *SpanRecorder) Spans() []*RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*RecordedSpan(nil), r.spans...)
+}
+
+func (s *RecordedSpan) SetAttribute(key, value string) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.Attributes[key] = value
+}
+
+func (s *RecordedSpan) RecordError(err error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.Errors = append(s.Errors, err)
+}
+
+func (s *RecordedSpan) End() {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.Ended = true
+}
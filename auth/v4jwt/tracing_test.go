@@ -0,0 +1,80 @@
+package v4jwt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := NewSpanRecorder()
+	config := NewConfig(jwt.SigningMethodHS256, []byte("secret"), WithKeyID("key-1"), WithTracer(recorder))
+	validator := NewValidator[jwt.Claims](config)
+	m := NewJwtMiddleware(AuthHeaderExtractor, validator, nil, &jwt.RegisteredClaims{}, WithRequestTracer(recorder))
+	h := m.CheckJwt(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	newToken := func(config *Config) string {
+		token, err := NewCreator(config).CreateToken(&jwt.RegisteredClaims{
+			Issuer:    "https://auth.example.com",
+			Subject:   "user-1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	serve := func(token string) []*RecordedSpan {
+		before := len(recorder.Spans())
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		h.ServeHTTP(httptest.NewRecorder(), r)
+		return recorder.Spans()[before:]
+	}
+
+	t.Run("인증 성공", func(t *testing.T) {
+		spans := serve(newToken(config))
+		require.Len(t, spans, 2)
+
+		checkJwt, validate := spans[0], spans[1]
+		assert.Equal(t, SpanCheckJwt, checkJwt.Name)
+		assert.Equal(t, SpanValidateToken, validate.Name)
+		assert.Same(t, checkJwt, validate.Parent)
+
+		for _, span := range spans {
+			assert.True(t, span.Ended)
+			assert.Equal(t, map[string]string{
+				AttributeAlg:     "HS256",
+				AttributeKeyID:   "key-1",
+				AttributeIssuer:  "https://auth.example.com",
+				AttributeOutcome: "valid",
+				AttributeSubject: "user-1",
+			}, span.Attributes)
+		}
+	})
+
+	t.Run("서명 오류", func(t *testing.T) {
+		spans := serve(newToken(NewConfig(jwt.SigningMethodHS256, []byte("other"))))
+		require.Len(t, spans, 2)
+
+		for _, span := range spans {
+			assert.Equal(t, "rejected", span.Attributes[AttributeOutcome])
+			assert.Equal(t, "signature", span.Attributes[AttributeErrorClass])
+			assert.NotContains(t, span.Attributes, AttributeSubject)
+			require.Len(t, span.Errors, 1)
+			assert.ErrorIs(t, span.Errors[0], ErrTokenSignatureInvalid)
+		}
+	})
+
+	t.Run("토큰 누락", func(t *testing.T) {
+		spans := serve("")
+		require.Len(t, spans, 1)
+		assert.Equal(t, "missing", spans[0].Attributes[AttributeErrorClass])
+	})
+}
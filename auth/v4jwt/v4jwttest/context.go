@@ -0,0 +1,25 @@
+package v4jwttest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+// ContextWithClaims: JwtMiddleware 를 거치지 않고 ctx 에 검증된 클레임 저장
+// v4jwt.ClaimsFromContext 로 claims 와 같은 타입으로 조회 가능
+func ContextWithClaims(ctx context.Context, claims jwt.Claims) context.Context {
+	return context.WithValue(ctx, v4jwt.ContextKey{}, claims)
+}
+
+// RequestWithClaims: 클레임이 저장된 context 를 가진 요청 사본
+func RequestWithClaims(r *http.Request, claims jwt.Claims) *http.Request {
+	return r.WithContext(ContextWithClaims(r.Context(), claims))
+}
+
+// RequestWithTenant: tenant 가 저장된 context 를 가진 요청 사본
+func RequestWithTenant(r *http.Request, tenant *v4jwt.Tenant) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), v4jwt.TenantContextKey{}, tenant))
+}
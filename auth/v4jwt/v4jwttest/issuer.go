@@ -0,0 +1,193 @@
+package v4jwttest
+
+import (
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/keys"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+const (
+	DefaultIssuer   = "https://issuer.example.test"
+	DefaultAudience = "test-audience"
+	DefaultKeyID    = "test-key"
+	// DefaultTTL: Token 으로 발급하는 토큰의 유효 기간
+	DefaultTTL = time.Hour
+)
+
+// TokenIssuer: 테스트마다 새로 생성하는 키 쌍과 Creator
+// 토큰 생성에 실패하면 t.Fatal 로 테스트를 중단
+type TokenIssuer struct {
+	Issuer   string
+	Audience string
+	KeyID    string
+	// Key: 서명에 사용하는 개인키, HS* 알고리즘은 nil
+	Key     crypto.Signer
+	Config  *v4jwt.Config
+	Creator *v4jwt.Creator
+
+	t   testing.TB
+	alg string
+}
+
+type Option func(*TokenIssuer)
+
+// WithAlg: 서명 알고리즘, 기본값은 키 생성이 빠른 ES256
+func WithAlg(alg string) Option {
+	return func(i *TokenIssuer) {
+		i.alg = alg
+	}
+}
+
+// WithIssuer: iss 클레임과 검증할 issuer
+func WithIssuer(issuer string) Option {
+	return func(i *TokenIssuer) {
+		i.Issuer = issuer
+	}
+}
+
+// WithAudience: aud 클레임과 검증할 audience
+func WithAudience(audience string) Option {
+	return func(i *TokenIssuer) {
+		i.Audience = audience
+	}
+}
+
+// WithKeyID: 토큰 헤더의 kid
+func WithKeyID(keyID string) Option {
+	return func(i *TokenIssuer) {
+		i.KeyID = keyID
+	}
+}
+
+// NewTokenIssuer: 새 키로 서명하는 TokenIssuer 생성
+func NewTokenIssuer(t testing.TB, opts ...Option) *TokenIssuer {
+	t.Helper()
+	i := &TokenIssuer{
+		Issuer:   DefaultIssuer,
+		Audience: DefaultAudience,
+		KeyID:    DefaultKeyID,
+		t:        t,
+		alg:      "ES256",
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	method := jwt.GetSigningMethod(i.alg)
+	if method == nil {
+		t.Fatalf("v4jwttest: unsupported alg %q", i.alg)
+	}
+	key, err := keys.Generate(i.alg)
+	if err != nil {
+		t.Fatalf("v4jwttest: generate key: %v", err)
+	}
+
+	switch k := key.(type) {
+	case []byte:
+		i.Config = v4jwt.NewConfig(method, k, v4jwt.WithKeyID(i.KeyID))
+	case crypto.Signer:
+		i.Key = k
+		i.Config = v4jwt.NewKeyPairConfig(method, k, k.Public(), v4jwt.WithKeyID(i.KeyID))
+	}
+	i.Creator = v4jwt.NewCreator(i.Config)
+	return i
+}
+
+// NewValidator: TokenIssuer 의 키, issuer, audience 로 검증하는 Validator
+func NewValidator[T jwt.Claims](i *TokenIssuer, opts ...v4jwt.ValidatorOption) *v4jwt.Validator[T] {
+	opts = append([]v4jwt.ValidatorOption{v4jwt.WithIssuer(i.Issuer), v4jwt.WithAudience(i.Audience)}, opts...)
+	return v4jwt.NewValidator[T](i.Config, opts...)
+}
+
+// JWKS: 검증키의 JWK Set, HMAC 키는 공개할 수 없으므로 비어 있음
+func (i *TokenIssuer) JWKS() *v4jwt.JWKS {
+	i.t.Helper()
+	jwks, err := v4jwt.NewJWKS(i.Config)
+	if err != nil {
+		i.t.Fatalf("v4jwttest: jwks: %v", err)
+	}
+	return jwks
+}
+
+// Claims: 현재 시각 (jwt.TimeFunc) 기준으로 DefaultTTL 동안 유효한 클레임
+func (i *TokenIssuer) Claims(subject string) *jwt.RegisteredClaims {
+	i.t.Helper()
+	id, err := v4jwt.NewTokenID()
+	if err != nil {
+		i.t.Fatalf("v4jwttest: token id: %v", err)
+	}
+
+	now := jwt.TimeFunc()
+	return &jwt.RegisteredClaims{
+		Issuer:    i.Issuer,
+		Subject:   subject,
+		Audience:  jwt.ClaimStrings{i.Audience},
+		ExpiresAt: jwt.NewNumericDate(now.Add(DefaultTTL)),
+		NotBefore: jwt.NewNumericDate(now),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ID:        id,
+	}
+}
+
+// Token: subject 에게 발급한 유효한 토큰
+func (i *TokenIssuer) Token(subject string) string {
+	i.t.Helper()
+	return i.TokenWithClaims(i.Claims(subject))
+}
+
+// TokenWithClaims: 주어진 클레임을 그대로 서명한 토큰
+func (i *TokenIssuer) TokenWithClaims(claims jwt.Claims) string {
+	i.t.Helper()
+	token, err := i.Creator.CreateToken(claims)
+	if err != nil {
+		i.t.Fatalf("v4jwttest: create token: %v", err)
+	}
+	return token
+}
+
+// ExpiredToken: 1분 전에 만료된 토큰
+func (i *TokenIssuer) ExpiredToken(subject string) string {
+	i.t.Helper()
+	claims := i.Claims(subject)
+	now := jwt.TimeFunc()
+	claims.IssuedAt = jwt.NewNumericDate(now.Add(-DefaultTTL))
+	claims.NotBefore = claims.IssuedAt
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+	return i.TokenWithClaims(claims)
+}
+
+// NotYetValidToken: 1시간 뒤부터 유효한 (nbf) 토큰
+func (i *TokenIssuer) NotYetValidToken(subject string) string {
+	i.t.Helper()
+	claims := i.Claims(subject)
+	claims.NotBefore = jwt.NewNumericDate(jwt.TimeFunc().Add(time.Hour))
+	claims.ExpiresAt = jwt.NewNumericDate(claims.NotBefore.Add(DefaultTTL))
+	return i.TokenWithClaims(claims)
+}
+
+// WrongSignatureToken: 같은 alg, kid 이지만 다른 키로 서명한 토큰
+func (i *TokenIssuer) WrongSignatureToken(subject string) string {
+	i.t.Helper()
+	other := NewTokenIssuer(i.t, WithAlg(i.alg), WithIssuer(i.Issuer), WithAudience(i.Audience), WithKeyID(i.KeyID))
+	return other.Token(subject)
+}
+
+// WrongAudienceToken: 다른 audience 에게 발급된 토큰
+func (i *TokenIssuer) WrongAudienceToken(subject string) string {
+	i.t.Helper()
+	claims := i.Claims(subject)
+	claims.Audience = jwt.ClaimStrings{"other-" + i.Audience}
+	return i.TokenWithClaims(claims)
+}
+
+// WrongIssuerToken: 다른 issuer 가 발급한 것으로 표시된 토큰
+func (i *TokenIssuer) WrongIssuerToken(subject string) string {
+	i.t.Helper()
+	claims := i.Claims(subject)
+	claims.Issuer = i.Issuer + "/other"
+	return i.TokenWithClaims(claims)
+}
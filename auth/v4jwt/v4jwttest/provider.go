@@ -0,0 +1,60 @@
+package v4jwttest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nookcoder/go-boilerplate/auth/oidc"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+// JWKSPath: Provider 가 JWKS 를 제공하는 경로
+const JWKSPath = "/jwks.json"
+
+// Provider: discovery 문서와 JWKS 를 제공하는 httptest 기반 가짜 OpenID Provider
+// issuer 는 서버 URL 이며, 테스트가 끝나면 서버를 종료
+type Provider struct {
+	*TokenIssuer
+	Server   *httptest.Server
+	Metadata *oidc.ProviderMetadata
+}
+
+// NewProvider: 가짜 OpenID Provider 시작, WithIssuer 옵션은 서버 URL 로 대체됨
+func NewProvider(t testing.TB, opts ...Option) *Provider {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	issuer := NewTokenIssuer(t, append(opts, WithIssuer(server.URL))...)
+	metadata := &oidc.ProviderMetadata{
+		Issuer:                           server.URL,
+		AuthorizationEndpoint:            server.URL + "/authorize",
+		TokenEndpoint:                    server.URL + "/token",
+		JWKSURI:                          server.URL + JWKSPath,
+		ResponseTypesSupported:           []string{"code"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{issuer.Config.Method().Alg()},
+	}
+
+	mux.Handle(oidc.DiscoveryPath, oidc.DiscoveryHandler(metadata))
+	mux.Handle(JWKSPath, v4jwt.JWKSHandler(issuer.JWKS()))
+
+	return &Provider{
+		TokenIssuer: issuer,
+		Server:      server,
+		Metadata:    metadata,
+	}
+}
+
+// URL: issuer 이자 discovery 문서의 기준 URL
+func (p *Provider) URL() string {
+	return p.Server.URL
+}
+
+// KeySet: Provider 의 JWKS 를 가져오는 RemoteKeySet
+func (p *Provider) KeySet(opts ...v4jwt.RemoteKeySetOption) *v4jwt.RemoteKeySet {
+	opts = append([]v4jwt.RemoteKeySetOption{v4jwt.WithHTTPClient(p.Server.Client())}, opts...)
+	return v4jwt.NewRemoteKeySet(p.Metadata.JWKSURI, opts...)
+}
@@ -0,0 +1,87 @@
+package v4jwttest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/oidc"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenIssuer(t *testing.T) {
+	for _, alg := range []string{"ES256", "HS256", "EdDSA"} {
+		alg := alg
+		t.Run(alg, func(t *testing.T) {
+			t.Parallel()
+			issuer := NewTokenIssuer(t, WithAlg(alg))
+			validator := NewValidator[*jwt.RegisteredClaims](issuer)
+
+			testCases := []struct {
+				name  string
+				token string
+				class string
+			}{
+				{name: "유효한 토큰", token: issuer.Token("user-1")},
+				{name: "만료된 토큰", token: issuer.ExpiredToken("user-1"), class: "expired"},
+				{name: "아직 유효하지 않은 토큰", token: issuer.NotYetValidToken("user-1"), class: "not_valid_yet"},
+				{name: "다른 키로 서명된 토큰", token: issuer.WrongSignatureToken("user-1"), class: "signature"},
+				{name: "다른 audience 토큰", token: issuer.WrongAudienceToken("user-1"), class: "audience"},
+				{name: "다른 issuer 토큰", token: issuer.WrongIssuerToken("user-1"), class: "issuer"},
+			}
+
+			for _, tc := range testCases {
+				claims, err := validator.ValidateToken(tc.token, &jwt.RegisteredClaims{})
+				assert.Equal(t, tc.class, v4jwt.ErrorClass(err), tc.name)
+				if tc.class == "" {
+					require.NoError(t, err)
+					assert.Equal(t, "user-1", claims.Subject)
+				}
+			}
+		})
+	}
+}
+
+func TestProvider(t *testing.T) {
+	provider := NewProvider(t, WithAudience("client-1"))
+	assert.Equal(t, provider.URL(), provider.Issuer)
+
+	verifier, err := oidc.NewVerifier(context.Background(), provider.URL(), "client-1",
+		oidc.WithVerifierHTTPClient(provider.Server.Client()))
+	require.NoError(t, err)
+
+	claims, err := verifier.Verify(context.Background(), provider.Token("user-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+
+	_, err = verifier.Verify(context.Background(), provider.WrongSignatureToken("user-1"))
+	assert.ErrorIs(t, err, v4jwt.ErrTokenSignatureInvalid)
+
+	jwk, err := provider.KeySet().Key(context.Background(), DefaultKeyID)
+	require.NoError(t, err)
+	assert.Equal(t, "ES256", jwk.Alg)
+}
+
+func TestRequestWithClaims(t *testing.T) {
+	claims := &jwt.RegisteredClaims{Subject: "user-1"}
+
+	var subject string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := v4jwt.ClaimsFromContext[*jwt.RegisteredClaims](r.Context())
+		require.True(t, ok)
+		subject = c.Subject
+	})
+
+	r := RequestWithClaims(httptest.NewRequest(http.MethodGet, "/", nil), claims)
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, "user-1", subject)
+
+	r = RequestWithTenant(r, &v4jwt.Tenant{ID: "tenant-1"})
+	tenant, ok := v4jwt.TenantFromContext(r.Context())
+	require.True(t, ok)
+	assert.Equal(t, "tenant-1", tenant.ID)
+}
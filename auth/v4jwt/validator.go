@@ -1,33 +1,161 @@
 package v4jwt
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/token"
 )
 
 type Validator[T jwt.Claims] struct {
 	*Config
+	validatorOptions
 }
 
-func NewValidator[T jwt.Claims](config *Config) *Validator[T] {
-	return &Validator[T]{
+// validatorOptions: 클레임 타입과 무관한 Validator 옵션
+type validatorOptions struct {
+	replayCache   ReplayCache
+	expectedTypes []string
+	issuer        string
+	audience      string
+	tokenCache    *tokenCache
+}
+
+type ValidatorOption func(*validatorOptions)
+
+// WithIssuer: iss 클레임이 issuer 와 일치하는 토큰만 허용
+func WithIssuer(issuer string) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.issuer = issuer
+	}
+}
+
+// WithAudience: aud 클레임에 audience 가 포함된 토큰만 허용
+func WithAudience(audience string) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.audience = audience
+	}
+}
+
+func NewValidator[T jwt.Claims](config *Config, opts ...ValidatorOption) *Validator[T] {
+	v := &Validator[T]{
 		Config: config,
 	}
+	for _, opt := range opts {
+		opt(&v.validatorOptions)
+	}
+	return v
 }
 
 func (v *Validator[T]) ValidateToken(tokenString string, claims T) (T, error) {
+	return v.ValidateTokenContext(context.Background(), tokenString, claims)
+}
+
+// ValidateTokenContext: ctx 의 span 을 부모로 트레이싱하는 ValidateToken
+func (v *Validator[T]) ValidateTokenContext(ctx context.Context, tokenString string, claims T) (T, error) {
+	if v.Config.metrics == nil && v.Config.tracer == nil {
+		return v.validateToken(tokenString, claims)
+	}
+
+	var span Span
+	if v.Config.tracer != nil {
+		_, span = v.Config.tracer.Start(ctx, SpanValidateToken)
+		defer span.End()
+	}
+
+	start := time.Now()
+	validated, err := v.validateToken(tokenString, claims)
+	if v.Config.metrics != nil {
+		alg := metricAlg(tokenString)
+		if err != nil {
+			v.Config.metrics.TokenRejected(OperationValidate, alg, ErrorClass(err), time.Since(start))
+		} else {
+			v.Config.metrics.TokenValidated(OperationValidate, alg, time.Since(start))
+		}
+	}
+	if span != nil {
+		setTokenSpanAttributes(span, tokenString, err)
+	}
+	return validated, err
+}
+
+func (v *Validator[T]) validateToken(tokenString string, claims T) (T, error) {
 	var empty T
-    token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-        if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-            return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
-        }
-        return v.Config.secretKey, nil
-    })
-    
-    if err != nil { 
-        return empty, err 
-    }   
-
-    return token.Claims.(T), nil
-}
\ No newline at end of file
+
+	// 1회용 토큰은 캐시하지 않음
+	cacheable := v.tokenCache != nil && v.replayCache == nil
+	var cacheKey [32]byte
+	if cacheable {
+		cacheKey = sha256.Sum256([]byte(tokenString))
+		if v.tokenCache.get(cacheKey) {
+			if _, _, err := new(jwt.Parser).ParseUnverified(tokenString, claims); err == nil {
+				return claims, nil
+			}
+		}
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, v.keyFunc)
+	if err != nil {
+		return empty, err
+	}
+
+	if v.issuer != "" || v.audience != "" || v.replayCache != nil || cacheable {
+		registered, err := registeredClaimsOf(token)
+		if err != nil {
+			return empty, err
+		}
+		if v.issuer != "" && !registered.VerifyIssuer(v.issuer, true) {
+			return empty, ErrTokenInvalidIssuer
+		}
+		if v.audience != "" && !registered.VerifyAudience(v.audience, true) {
+			return empty, ErrTokenInvalidAudience
+		}
+		if v.replayCache != nil {
+			if err := v.checkReplay(registered); err != nil {
+				return empty, err
+			}
+		}
+		if cacheable {
+			v.tokenCache.add(cacheKey, registered)
+		}
+	}
+
+	return token.Claims.(T), nil
+}
+
+// registeredClaimsOf: 클레임 타입과 무관하게 서명 검증이 끝난 토큰의 등록 클레임 조회
+func registeredClaimsOf(token *jwt.Token) (*jwt.RegisteredClaims, error) {
+	parts := strings.Split(token.Raw, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenMalformed
+	}
+	payload, err := jwt.DecodeSegment(parts[1])
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, ErrTokenMalformed
+	}
+	return claims, nil
+}
+
+// keyFunc: 설정된 알고리즘과 헤더의 alg 가 정확히 일치하는 경우에만 검증키 반환
+// alg 를 바꿔치기 하는 공격 (RS256 -> HS256 등) 방지
+func (v *Validator[T]) keyFunc(t *jwt.Token) (interface{}, error) {
+	if t.Method.Alg() != v.Config.method.Alg() {
+		return nil, fmt.Errorf("Unexpected signing method: %v", t.Header["alg"])
+	}
+	typ, _ := t.Header["typ"].(string)
+	// 액션 토큰은 ActionTokens 로만 검증
+	if err := token.CheckTokenType(v.expectedTypes, typ); err != nil {
+		return nil, err
+	}
+	return v.Config.verifyKeyFor(t)
+}
@@ -0,0 +1,127 @@
+package v4jwt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultVaultField = "secret"
+
+// VaultKVProvider: Vault KV v2 API (GET /v1/<mount>/data/<path>) 에서 secret 조회
+// data.metadata.version 을 버전으로, lease_duration 을 사용 기간으로 사용
+type VaultKVProvider struct {
+	address   string
+	mount     string
+	path      string
+	field     string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+type VaultOption func(*VaultKVProvider)
+
+// WithVaultToken: X-Vault-Token 헤더 값
+func WithVaultToken(token string) VaultOption {
+	return func(p *VaultKVProvider) {
+		p.token = token
+	}
+}
+
+// WithVaultField: secret 이 저장된 키 이름, 기본값은 "secret"
+func WithVaultField(field string) VaultOption {
+	return func(p *VaultKVProvider) {
+		p.field = field
+	}
+}
+
+// WithVaultNamespace: Vault Enterprise 의 X-Vault-Namespace 헤더 값
+func WithVaultNamespace(namespace string) VaultOption {
+	return func(p *VaultKVProvider) {
+		p.namespace = namespace
+	}
+}
+
+func WithVaultHTTPClient(client *http.Client) VaultOption {
+	return func(p *VaultKVProvider) {
+		p.client = client
+	}
+}
+
+// NewVaultKVProvider: address 는 https://vault.example.com:8200 처럼 /v1 을 제외한 주소
+func NewVaultKVProvider(address, mount, path string, opts ...VaultOption) *VaultKVProvider {
+	p := &VaultKVProvider{
+		address: strings.TrimRight(address, "/"),
+		mount:   strings.Trim(mount, "/"),
+		path:    strings.Trim(path, "/"),
+		field:   defaultVaultField,
+		client:  http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+type vaultKVResponse struct {
+	LeaseDuration int `json:"lease_duration"`
+	Data          struct {
+		Data     map[string]interface{} `json:"data"`
+		Metadata struct {
+			Version int `json:"version"`
+		} `json:"metadata"`
+	} `json:"data"`
+}
+
+func (p *VaultKVProvider) Secret(ctx context.Context) (*Secret, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", p.address, p.mount, p.path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "" {
+		req.Header.Set("X-Vault-Token", p.token)
+	}
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch vault secret: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: vault %s/%s", ErrSecretNotFound, p.mount, p.path)
+	default:
+		return nil, fmt.Errorf("fetch vault secret: unexpected status %d", resp.StatusCode)
+	}
+
+	var body vaultKVResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode vault secret: %w", err)
+	}
+	value, _ := body.Data.Data[p.field].(string)
+	if value == "" {
+		return nil, fmt.Errorf("%w: vault %s/%s field %q", ErrSecretNotFound, p.mount, p.path, p.field)
+	}
+
+	var version string
+	if body.Data.Metadata.Version > 0 {
+		version = strconv.Itoa(body.Data.Metadata.Version)
+	}
+	secret, err := newSecret([]byte(value), version)
+	if err != nil {
+		return nil, err
+	}
+	secret.LeaseDuration = time.Duration(body.LeaseDuration) * time.Second
+	return secret, nil
+}
@@ -0,0 +1,53 @@
+package v5jwt
+
+import (
+	"encoding/json"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+// adapter: token.Claims 를 golang-jwt v5 의 jwt.Claims 로 사용하기 위한 어댑터
+// 직렬화는 감싼 클레임을 그대로 사용
+type adapter struct {
+	claims token.Claims
+}
+
+func (a *adapter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.claims)
+}
+
+func (a *adapter) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, a.claims)
+}
+
+func (a *adapter) GetExpirationTime() (*jwt.NumericDate, error) {
+	return numericDate(a.claims.GetRegisteredClaims().ExpiresAt), nil
+}
+
+func (a *adapter) GetIssuedAt() (*jwt.NumericDate, error) {
+	return numericDate(a.claims.GetRegisteredClaims().IssuedAt), nil
+}
+
+func (a *adapter) GetNotBefore() (*jwt.NumericDate, error) {
+	return numericDate(a.claims.GetRegisteredClaims().NotBefore), nil
+}
+
+func (a *adapter) GetIssuer() (string, error) {
+	return a.claims.GetRegisteredClaims().Issuer, nil
+}
+
+func (a *adapter) GetSubject() (string, error) {
+	return a.claims.GetRegisteredClaims().Subject, nil
+}
+
+func (a *adapter) GetAudience() (jwt.ClaimStrings, error) {
+	return jwt.ClaimStrings(a.claims.GetRegisteredClaims().Audience), nil
+}
+
+func numericDate(d *token.NumericDate) *jwt.NumericDate {
+	if d == nil {
+		return nil
+	}
+	return jwt.NewNumericDate(d.Time)
+}
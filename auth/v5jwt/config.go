@@ -0,0 +1,74 @@
+package v5jwt
+
+import (
+	"crypto"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type Config struct {
+	method    jwt.SigningMethod
+	secretKey []byte
+	// RSA, ECDSA, EdDSA 처럼 서명키와 검증키가 다른 경우 사용
+	signingKey crypto.PrivateKey
+	verifyKey  crypto.PublicKey
+	keyID      string
+}
+
+type ConfigOption func(*Config)
+
+// WithKeyID: 토큰 헤더의 kid 값 설정
+func WithKeyID(keyID string) ConfigOption {
+	return func(c *Config) {
+		c.keyID = keyID
+	}
+}
+
+func NewConfig(method jwt.SigningMethod, secretKey []byte, opts ...ConfigOption) *Config {
+	c := &Config{
+		method:    method,
+		secretKey: secretKey,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewKeyPairConfig: 비대칭 키 설정
+// 검증만 하는 경우 signingKey 는 nil 로 설정
+func NewKeyPairConfig(method jwt.SigningMethod, signingKey crypto.PrivateKey, verifyKey crypto.PublicKey, opts ...ConfigOption) *Config {
+	c := &Config{
+		method:     method,
+		signingKey: signingKey,
+		verifyKey:  verifyKey,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Config) Method() jwt.SigningMethod {
+	return c.method
+}
+
+func (c *Config) KeyID() string {
+	return c.keyID
+}
+
+// SigningKey: 토큰 서명에 사용하는 키
+func (c *Config) SigningKey() interface{} {
+	if c.signingKey != nil {
+		return c.signingKey
+	}
+	return c.secretKey
+}
+
+// VerifyKey: 토큰 검증에 사용하는 키
+func (c *Config) VerifyKey() interface{} {
+	if c.verifyKey != nil {
+		return c.verifyKey
+	}
+	return c.secretKey
+}
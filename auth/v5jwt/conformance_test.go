@@ -0,0 +1,46 @@
+package v5jwt
+
+import (
+	"crypto"
+	"net/http"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+	"github.com/nookcoder/go-boilerplate/auth/token/tokentest"
+)
+
+func TestConformance(t *testing.T) {
+	fixtures := tokentest.JWTFixtures(t, func(alg string, signingKey crypto.PrivateKey, verifyKey crypto.PublicKey) *Config {
+		if secret, ok := signingKey.([]byte); ok {
+			return NewConfig(jwt.GetSigningMethod(alg), secret)
+		}
+		return NewKeyPairConfig(jwt.GetSigningMethod(alg), signingKey, verifyKey)
+	})
+
+	tokentest.RunFixtures(t, fixtures, func(f tokentest.Fixture[*Config]) tokentest.Backend {
+		return tokentest.Backend{
+			NewCreator: func(typ string) token.Creator {
+				return NewCreator(f.Config, WithTokenType(typ))
+			},
+			OtherKeyCreator: NewCreator(f.OtherKey),
+			OtherAlgCreator: NewCreator(f.OtherAlg),
+			NewValidator: func(o tokentest.ValidatorOptions) token.Validator[token.Claims] {
+				var opts []ValidatorOption
+				if o.Issuer != "" {
+					opts = append(opts, WithIssuer(o.Issuer))
+				}
+				if o.Audience != "" {
+					opts = append(opts, WithAudience(o.Audience))
+				}
+				if len(o.ExpectedTypes) > 0 {
+					opts = append(opts, WithExpectedTypes(o.ExpectedTypes...))
+				}
+				return NewValidator[token.Claims](f.Config, opts...)
+			},
+			NewMiddleware: func(validator token.Validator[token.Claims], claims token.Claims) func(http.Handler) http.Handler {
+				return NewJwtMiddleware(token.AuthHeaderExtractor, validator, nil, claims).CheckJwt
+			},
+		}
+	})
+}
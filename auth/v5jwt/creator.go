@@ -0,0 +1,56 @@
+package v5jwt
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+type Creator struct {
+	*Config
+	tokenType string
+}
+
+type CreatorOption func(*Creator)
+
+// WithTokenType: 발급하는 토큰 헤더의 typ (예: at+jwt)
+func WithTokenType(typ string) CreatorOption {
+	return func(c *Creator) {
+		c.tokenType = typ
+	}
+}
+
+func NewCreator(config *Config, opts ...CreatorOption) *Creator {
+	c := &Creator{
+		Config: config,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Creator) CreateToken(claims token.Claims) (string, error) {
+	return c.createToken(claims, c.tokenType)
+}
+
+// CreateTypedToken: 헤더의 typ 을 지정하여 토큰 생성
+func (c *Creator) CreateTypedToken(claims token.Claims, typ string) (string, error) {
+	return c.createToken(claims, typ)
+}
+
+// createToken: claims 가 nil 이면 빈 클레임으로 생성
+func (c *Creator) createToken(claims token.Claims, typ string) (string, error) {
+	if claims == nil {
+		claims = &token.RegisteredClaims{}
+	}
+	t := jwt.NewWithClaims(c.Config.method, &adapter{claims: claims})
+
+	if c.Config.keyID != "" {
+		t.Header["kid"] = c.Config.keyID
+	}
+	if typ != "" {
+		t.Header["typ"] = typ
+	}
+
+	return t.SignedString(c.Config.SigningKey())
+}
@@ -0,0 +1,79 @@
+package v5jwt
+
+import (
+	"errors"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+/**
+* golang-jwt v5 에서 제공하는 Error Constant 와 동일한 값을 사용
+* 검증 에러는 token 패키지의 에러와 함께 감싸서 반환하므로 token.ErrTokenExpired 등으로도 확인 가능
+ */
+var (
+	ErrInvalidKey                = jwt.ErrInvalidKey
+	ErrInvalidKeyType            = jwt.ErrInvalidKeyType
+	ErrHashUnavailable           = jwt.ErrHashUnavailable
+	ErrTokenMalformed            = jwt.ErrTokenMalformed
+	ErrTokenUnverifiable         = jwt.ErrTokenUnverifiable
+	ErrTokenSignatureInvalid     = jwt.ErrTokenSignatureInvalid
+	ErrTokenRequiredClaimMissing = jwt.ErrTokenRequiredClaimMissing
+	ErrTokenInvalidAudience      = jwt.ErrTokenInvalidAudience
+	ErrTokenExpired              = jwt.ErrTokenExpired
+	ErrTokenUsedBeforeIssued     = jwt.ErrTokenUsedBeforeIssued
+	ErrTokenInvalidIssuer        = jwt.ErrTokenInvalidIssuer
+	ErrTokenInvalidSubject       = jwt.ErrTokenInvalidSubject
+	ErrTokenNotValidYet          = jwt.ErrTokenNotValidYet
+	ErrTokenInvalidId            = jwt.ErrTokenInvalidId
+	ErrTokenInvalidClaims        = jwt.ErrTokenInvalidClaims
+)
+
+var (
+	ErrTokenTypeMismatch = token.ErrTokenTypeMismatch
+	ErrActionTokenMisuse = token.ErrActionTokenMisuse
+)
+
+// tokenErrors: golang-jwt v5 에러와 대응하는 token 패키지 에러
+var tokenErrors = []struct {
+	jwt   error
+	token error
+}{
+	{jwt.ErrTokenMalformed, token.ErrTokenMalformed},
+	{jwt.ErrTokenSignatureInvalid, token.ErrTokenSignatureInvalid},
+	{jwt.ErrTokenExpired, token.ErrTokenExpired},
+	{jwt.ErrTokenNotValidYet, token.ErrTokenNotValidYet},
+	{jwt.ErrTokenUsedBeforeIssued, token.ErrTokenUsedBeforeIssued},
+	{jwt.ErrTokenInvalidIssuer, token.ErrTokenInvalidIssuer},
+	{jwt.ErrTokenInvalidAudience, token.ErrTokenInvalidAudience},
+	{jwt.ErrTokenUnverifiable, token.ErrTokenUnverifiable},
+	{jwt.ErrTokenInvalidClaims, token.ErrTokenInvalidClaims},
+}
+
+// validationError: 메시지는 golang-jwt 의 에러를 그대로 사용하고,
+// errors.Is 로 golang-jwt 와 token 패키지의 에러를 모두 확인할 수 있도록 함
+type validationError struct {
+	err   error
+	kinds []error
+}
+
+func (e *validationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *validationError) Unwrap() []error {
+	return append([]error{e.err}, e.kinds...)
+}
+
+func wrapError(err error) error {
+	var kinds []error
+	for _, e := range tokenErrors {
+		if errors.Is(err, e.jwt) {
+			kinds = append(kinds, e.token)
+		}
+	}
+	if len(kinds) == 0 {
+		return err
+	}
+	return &validationError{err: err, kinds: kinds}
+}
@@ -0,0 +1,31 @@
+package v5jwt
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+// JwtMiddleware: token.Middleware 를 사용하는 v4jwt.JwtMiddleware 와 같은 동작의 미들웨어
+// 추출기, 에러 핸들러, 컨텍스트 키는 token 패키지의 것을 사용
+type JwtMiddleware struct {
+	middleware *token.Middleware
+}
+
+// NewJwtMiddleware: claims 는 요청마다 같은 타입의 새 인스턴스로 복제되어 사용됨
+// errorHandler 가 nil 인 경우 token.DefaultErrorHandler 사용
+func NewJwtMiddleware(extractor token.Extractor, validator token.Validator[token.Claims], errorHandler token.ErrorHandler, claims token.Claims, opts ...token.MiddlewareOption) *JwtMiddleware {
+	return &JwtMiddleware{
+		middleware: token.NewMiddleware(extractor, validator, errorHandler, claims, opts...),
+	}
+}
+
+func (m *JwtMiddleware) CheckJwt(next http.Handler) http.Handler {
+	return m.middleware.Handler(next)
+}
+
+// ClaimsFromContext: JwtMiddleware 가 저장한 클레임 조회
+func ClaimsFromContext[T token.Claims](ctx context.Context) (T, bool) {
+	return token.ClaimsFromContext[T](ctx)
+}
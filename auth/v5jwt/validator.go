@@ -0,0 +1,97 @@
+package v5jwt
+
+import (
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+)
+
+type Validator[T token.Claims] struct {
+	*Config
+	validatorOptions
+	parser *jwt.Parser
+}
+
+type ValidatorOption func(*validatorOptions)
+
+// validatorOptions: 클레임 타입과 무관한 Validator 옵션
+type validatorOptions struct {
+	expectedTypes []string
+	issuer        string
+	audience      string
+	parserOptions []jwt.ParserOption
+}
+
+// WithIssuer: iss 클레임이 issuer 와 일치하는 토큰만 허용
+// jwt.WithIssuer 와 달리 iss 가 없는 경우도 ErrTokenInvalidIssuer 로 v4jwt 와 같게 처리
+func WithIssuer(issuer string) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.issuer = issuer
+	}
+}
+
+// WithAudience: aud 클레임에 audience 가 포함된 토큰만 허용
+func WithAudience(audience string) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.audience = audience
+	}
+}
+
+// WithExpectedTypes: 헤더의 typ 이 types 중 하나인 토큰만 허용
+func WithExpectedTypes(types ...string) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.expectedTypes = types
+	}
+}
+
+// WithParserOptions: golang-jwt v5 의 검증 옵션 (jwt.WithLeeway, jwt.WithExpirationRequired 등)
+func WithParserOptions(opts ...jwt.ParserOption) ValidatorOption {
+	return func(o *validatorOptions) {
+		o.parserOptions = append(o.parserOptions, opts...)
+	}
+}
+
+// NewValidator: v4jwt 와 같이 iat 이 현재 시각 이후인 토큰은 거부
+func NewValidator[T token.Claims](config *Config, opts ...ValidatorOption) *Validator[T] {
+	v := &Validator[T]{
+		Config: config,
+	}
+	for _, opt := range opts {
+		opt(&v.validatorOptions)
+	}
+
+	parserOptions := append([]jwt.ParserOption{jwt.WithIssuedAt()}, v.parserOptions...)
+	v.parser = jwt.NewParser(parserOptions...)
+	return v
+}
+
+func (v *Validator[T]) ValidateToken(tokenString string, claims T) (T, error) {
+	var empty T
+
+	if _, err := v.parser.ParseWithClaims(tokenString, &adapter{claims: claims}, v.keyFunc); err != nil {
+		return empty, wrapError(err)
+	}
+
+	registered := claims.GetRegisteredClaims()
+	if v.issuer != "" && registered.Issuer != v.issuer {
+		return empty, wrapError(ErrTokenInvalidIssuer)
+	}
+	if v.audience != "" && !registered.Audience.Contains(v.audience) {
+		return empty, wrapError(ErrTokenInvalidAudience)
+	}
+	return claims, nil
+}
+
+// keyFunc: 설정된 알고리즘과 헤더의 alg 가 정확히 일치하는 경우에만 검증키 반환
+func (v *Validator[T]) keyFunc(t *jwt.Token) (interface{}, error) {
+	if t.Method.Alg() != v.Config.method.Alg() {
+		return nil, fmt.Errorf("Unexpected signing method: %v", t.Header["alg"])
+	}
+	typ, _ := t.Header["typ"].(string)
+	// 액션 토큰 (v4jwt.ActionTokens) 은 access 토큰으로 사용할 수 없음
+	if err := token.CheckTokenType(v.expectedTypes, typ); err != nil {
+		return nil, err
+	}
+	return v.Config.VerifyKey(), nil
+}
@@ -0,0 +1,79 @@
+package v5jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nookcoder/go-boilerplate/auth/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorParserOptions(t *testing.T) {
+	config := NewConfig(jwt.SigningMethodHS256, []byte("v5jwt-test-secret-0123456789abcdef"))
+	creator := NewCreator(config)
+
+	expired, err := creator.CreateToken(&token.RegisteredClaims{
+		Subject:   "user-1",
+		ExpiresAt: token.NewNumericDate(time.Now().Add(-30 * time.Second)),
+	})
+	require.NoError(t, err)
+	noExp, err := creator.CreateToken(&token.RegisteredClaims{Subject: "user-1"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		tokenString   string
+		opts          []ValidatorOption
+		expectedError error
+	}{
+		{
+			name:          "만료",
+			tokenString:   expired,
+			expectedError: ErrTokenExpired,
+		},
+		{
+			name:        "leeway 안에서는 허용",
+			tokenString: expired,
+			opts:        []ValidatorOption{WithParserOptions(jwt.WithLeeway(time.Minute))},
+		},
+		{
+			name:        "exp 없음",
+			tokenString: noExp,
+		},
+		{
+			name:          "exp 필수",
+			tokenString:   noExp,
+			opts:          []ValidatorOption{WithParserOptions(jwt.WithExpirationRequired())},
+			expectedError: ErrTokenRequiredClaimMissing,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := NewValidator[*token.RegisteredClaims](config, tc.opts...).ValidateToken(tc.tokenString, &token.RegisteredClaims{})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", claims.Subject)
+		})
+	}
+}
+
+func TestValidatorErrors(t *testing.T) {
+	config := NewConfig(jwt.SigningMethodHS256, []byte("v5jwt-test-secret-0123456789abcdef"))
+	tokenString, err := NewCreator(config).CreateToken(&token.RegisteredClaims{
+		ExpiresAt: token.NewNumericDate(time.Now().Add(-time.Minute)),
+	})
+	require.NoError(t, err)
+
+	// golang-jwt v5 의 에러와 token 패키지의 에러로 모두 확인 가능
+	_, err = NewValidator[*token.RegisteredClaims](config).ValidateToken(tokenString, &token.RegisteredClaims{})
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+	assert.ErrorIs(t, err, token.ErrTokenExpired)
+	assert.Equal(t, "expired", token.ErrorClass(err))
+	assert.Contains(t, err.Error(), "token is expired")
+}
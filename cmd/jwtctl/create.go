@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/keys"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+func runCreate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("create", stderr)
+	alg := fs.String("alg", "", "signing algorithm (default: derived from the key)")
+	keyFile := fs.String("key", "", "private key (PEM or JWK) or HMAC secret file (required)")
+	kid := fs.String("kid", "", "key ID header (default: the kid of a JWK key file)")
+	typ := fs.String("typ", "", "typ header, e.g. at+jwt")
+	claimsJSON := fs.String("claims", "", "claims as JSON, or @file to read them from a file")
+	sub := fs.String("sub", "", "subject claim")
+	iss := fs.String("iss", "", "issuer claim")
+	exp := fs.Duration("exp", time.Hour, "lifetime; 0 omits the exp claim")
+	jti := fs.Bool("jti", true, "add a random jti claim")
+	var aud, extra stringList
+	fs.Var(&aud, "aud", "audience claim (repeatable)")
+	fs.Var(&extra, "claim", "additional string claim as name=value (repeatable)")
+
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if *keyFile == "" || fs.NArg() > 0 {
+		fmt.Fprintln(stderr, "jwtctl create: -key is required and no arguments are accepted")
+		fs.Usage()
+		return exitUsage
+	}
+
+	claims, err := readClaims(*claimsJSON)
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl create: %v\n", err)
+		return exitUsage
+	}
+
+	now := time.Now()
+	claims["iat"] = now.Unix()
+	if *exp > 0 {
+		claims["exp"] = now.Add(*exp).Unix()
+	}
+	if *sub != "" {
+		claims["sub"] = *sub
+	}
+	if *iss != "" {
+		claims["iss"] = *iss
+	}
+	if len(aud) == 1 {
+		claims["aud"] = aud[0]
+	} else if len(aud) > 1 {
+		claims["aud"] = []string(aud)
+	}
+	if *jti {
+		id, err := v4jwt.NewTokenID()
+		if err != nil {
+			fmt.Fprintf(stderr, "jwtctl create: %v\n", err)
+			return exitError
+		}
+		claims["jti"] = id
+	}
+	for _, kv := range extra {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || name == "" {
+			fmt.Fprintf(stderr, "jwtctl create: invalid -claim %q, expected name=value\n", kv)
+			return exitUsage
+		}
+		claims[name] = value
+	}
+
+	key, keyID, err := loadKey(*keyFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl create: %v\n", err)
+		return exitError
+	}
+	if *kid == "" {
+		*kid = keyID
+	}
+
+	if *alg == "" {
+		*alg = keys.Alg(key)
+	}
+	method := jwt.GetSigningMethod(*alg)
+	if method == nil || method == jwt.SigningMethodNone {
+		fmt.Fprintf(stderr, "jwtctl create: unsupported algorithm %q\n", *alg)
+		return exitUsage
+	}
+
+	config, err := signingConfig(method, key, *kid)
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl create: %v\n", err)
+		return exitError
+	}
+
+	token, err := v4jwt.NewCreator(config, v4jwt.WithTokenType(*typ)).CreateToken(claims)
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl create: %v\n", err)
+		return exitError
+	}
+
+	fmt.Fprintln(stdout, token)
+	return exitOK
+}
+
+// readClaims: JSON 문자열 또는 @file 에서 클레임 읽기
+func readClaims(value string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	if value == "" {
+		return claims, nil
+	}
+
+	b := []byte(value)
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		var err error
+		b, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal(b, &claims); err != nil {
+		return nil, fmt.Errorf("invalid claims JSON: %w", err)
+	}
+	return claims, nil
+}
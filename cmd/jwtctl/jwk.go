@@ -0,0 +1,98 @@
+package main
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/nookcoder/go-boilerplate/auth/keys"
+)
+
+func runJWK(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("jwk", stderr)
+	toPEM := fs.Bool("pem", false, "convert to PEM instead of JWK")
+	public := fs.Bool("public", false, "output only the public key")
+	alg := fs.String("alg", "", "alg member (default: derived from the key)")
+	kid := fs.String("kid", "", "kid member (default: the input kid or the RFC 7638 thumbprint)")
+	set := fs.Bool("set", false, "wrap the JWK in a JWK Set")
+
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "jwtctl jwk: expected a single key file")
+		fs.Usage()
+		return exitUsage
+	}
+
+	key, keyID, err := loadKey(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl jwk: %v\n", err)
+		return exitError
+	}
+	if *public {
+		if key, err = keys.Public(key); err != nil {
+			fmt.Fprintln(stderr, "jwtctl jwk: HMAC secrets have no public key")
+			return exitUsage
+		}
+	}
+
+	if *toPEM {
+		b, err := keys.EncodePEM(key)
+		if err != nil {
+			fmt.Fprintf(stderr, "jwtctl jwk: %v\n", err)
+			return exitError
+		}
+		stdout.Write(b)
+		return exitOK
+	}
+
+	if *kid == "" {
+		*kid = keyID
+	}
+	b, err := marshalJWK(key, *alg, *kid, *set)
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl jwk: %v\n", err)
+		return exitError
+	}
+	stdout.Write(b)
+	return exitOK
+}
+
+// marshalJWK: 키를 들여쓰기 된 JWK (또는 JWK Set) JSON 으로 변환
+// 개인키와 HMAC secret 은 개인키 멤버를 포함, kid 가 비어 있으면 thumbprint 사용
+func marshalJWK(key interface{}, alg, kid string, set bool) ([]byte, error) {
+	if alg == "" {
+		alg = keys.Alg(key)
+	}
+
+	var jwk keys.PrivateJWK
+	switch key.(type) {
+	case crypto.Signer, []byte:
+		private, err := keys.NewPrivateJWK(key, alg)
+		if err != nil {
+			return nil, err
+		}
+		jwk = private
+	default:
+		public, err := keys.NewPublicJWK(key, alg)
+		if err != nil {
+			return nil, err
+		}
+		jwk = keys.PrivateJWK{JWK: public}
+	}
+	if kid != "" {
+		jwk.Kid = kid
+	}
+
+	var v interface{} = jwk
+	if set {
+		v = map[string][]keys.PrivateJWK{"keys": {jwk}}
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
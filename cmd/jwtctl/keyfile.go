@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/keys"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+// loadKey: PEM, JWK 키 파일 또는 HMAC secret 파일 읽기
+// 둘 다 아니면 파일 내용 (끝의 개행 제외) 을 HMAC secret 으로 사용
+// JWK 파일은 kid 도 함께 반환
+func loadKey(path string) (interface{}, string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var key interface{}
+	var kid string
+	switch {
+	case bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")):
+		var jwk keys.PrivateJWK
+		if jwk, err = keys.ParseJWK(b); err == nil {
+			key, err = jwk.Key()
+			kid = jwk.Kid
+		}
+	case bytes.Contains(b, []byte("-----BEGIN ")):
+		key, err = keys.ParsePEM(b)
+	default:
+		secret := bytes.TrimRight(b, "\r\n")
+		if len(secret) == 0 {
+			return nil, "", fmt.Errorf("%s: empty key file", path)
+		}
+		return secret, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", path, err)
+	}
+	return key, kid, nil
+}
+
+// signingConfig: 서명에 사용할 Config, 비대칭 키는 개인키가 필요
+func signingConfig(method jwt.SigningMethod, key interface{}, kid string) (*v4jwt.Config, error) {
+	if secret, ok := key.([]byte); ok {
+		return v4jwt.NewConfig(method, secret, v4jwt.WithKeyID(kid)), nil
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("a private key is required to sign")
+	}
+	return v4jwt.NewKeyPairConfig(method, signer, signer.Public(), v4jwt.WithKeyID(kid)), nil
+}
+
+// verifyConfig: 검증에 사용할 Config, 개인키가 주어지면 공개키를 사용
+func verifyConfig(method jwt.SigningMethod, key interface{}) *v4jwt.Config {
+	switch k := key.(type) {
+	case []byte:
+		return v4jwt.NewConfig(method, k)
+	case crypto.Signer:
+		return v4jwt.NewKeyPairConfig(method, nil, k.Public())
+	default:
+		return v4jwt.NewKeyPairConfig(method, nil, k)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nookcoder/go-boilerplate/auth/keys"
+)
+
+func runKeygen(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("keygen", stderr)
+	keyType := fs.String("type", "ec", "key type: hmac, rsa, ec or ed25519")
+	bits := fs.Int("bits", 0, "RSA modulus or HMAC secret size in bits (default: 2048 for RSA, 512 for HMAC)")
+	curve := fs.String("curve", "P-256", "EC curve: P-256, P-384 or P-521")
+	format := fs.String("format", "pem", "output format: pem (plain text for HMAC secrets) or jwk")
+	alg := fs.String("alg", "", "alg member of JWK output (default: derived from the key)")
+	out := fs.String("out", "", "private key output file (default: stdout)")
+	pub := fs.String("pub", "", "public key output file")
+
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 || (*format != "pem" && *format != "jwk") {
+		fs.Usage()
+		return exitUsage
+	}
+
+	var key interface{}
+	var err error
+	switch *keyType {
+	case "hmac":
+		size := keys.DefaultSecretSize
+		if *bits > 0 {
+			size = *bits / 8
+		}
+		key, err = keys.GenerateSecret(size)
+	case "rsa":
+		size := keys.MinRSABits
+		if *bits > 0 {
+			size = *bits
+		}
+		key, err = keys.GenerateRSA(size)
+	case "ec":
+		key, err = keys.GenerateEC(*curve)
+	case "ed25519":
+		key, err = keys.GenerateEd25519()
+	default:
+		err = fmt.Errorf("unknown key type %q", *keyType)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl keygen: %v\n", err)
+		return exitUsage
+	}
+
+	private, err := encodeKey(key, *format, *alg)
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl keygen: %v\n", err)
+		return exitError
+	}
+
+	if *pub != "" {
+		public, err := keys.Public(key)
+		if err != nil {
+			fmt.Fprintln(stderr, "jwtctl keygen: HMAC secrets have no public key")
+			return exitUsage
+		}
+		b, err := encodeKey(public, *format, *alg)
+		if err != nil {
+			fmt.Fprintf(stderr, "jwtctl keygen: %v\n", err)
+			return exitError
+		}
+		if err := os.WriteFile(*pub, b, 0o644); err != nil {
+			fmt.Fprintf(stderr, "jwtctl keygen: %v\n", err)
+			return exitError
+		}
+	}
+
+	if *out == "" {
+		stdout.Write(private)
+		return exitOK
+	}
+	if err := os.WriteFile(*out, private, 0o600); err != nil {
+		fmt.Fprintf(stderr, "jwtctl keygen: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// encodeKey: pem 형식은 PKCS #8, PKIX PEM 이며 HMAC secret 은 base64url 텍스트
+func encodeKey(key interface{}, format, alg string) ([]byte, error) {
+	if format == "jwk" {
+		return marshalJWK(key, alg, "", false)
+	}
+	if secret, ok := key.([]byte); ok {
+		return []byte(base64.RawURLEncoding.EncodeToString(secret) + "\n"), nil
+	}
+	return keys.EncodePEM(key)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/keys"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runCLI(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestKeygenCreateVerify(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		alg  string
+	}{
+		{name: "HMAC", args: []string{"-type", "hmac"}, alg: "HS256"},
+		{name: "RSA", args: []string{"-type", "rsa"}, alg: "RS256"},
+		{name: "ECDSA P-384", args: []string{"-type", "ec", "-curve", "P-384"}, alg: "ES384"},
+		{name: "Ed25519", args: []string{"-type", "ed25519"}, alg: "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			private := filepath.Join(dir, "private")
+			public := private
+			args := append([]string{"keygen", "-out", private}, tt.args...)
+			if tt.alg != "HS256" {
+				public = filepath.Join(dir, "public.pem")
+				args = append(args, "-pub", public)
+			}
+
+			code, _, stderr := runCLI(t, "", args...)
+			require.Equal(t, exitOK, code, stderr)
+
+			code, token, stderr := runCLI(t, "", "create", "-key", private, "-kid", "key-1",
+				"-sub", "user-1", "-iss", "https://auth.example.com", "-aud", "api", "-claim", "role=admin")
+			require.Equal(t, exitOK, code, stderr)
+			token = strings.TrimSpace(token)
+
+			code, out, stderr := runCLI(t, token, "verify", "-key", public,
+				"-iss", "https://auth.example.com", "-aud", "api", "-")
+			require.Equal(t, exitOK, code, stderr)
+			assert.True(t, strings.HasPrefix(out, "valid\n"))
+
+			var claims map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(out, "valid\n")), &claims))
+			assert.Equal(t, "user-1", claims["sub"])
+			assert.Equal(t, "admin", claims["role"])
+			assert.NotEmpty(t, claims["jti"])
+
+			code, out, stderr = runCLI(t, "", "decode", token)
+			require.Equal(t, exitOK, code, stderr)
+			assert.Contains(t, out, `"alg": "`+tt.alg+`"`)
+			assert.Contains(t, out, `"kid": "key-1"`)
+			assert.Contains(t, out, "exp")
+			assert.Contains(t, out, "Signature: not verified")
+		})
+	}
+}
+
+func TestVerifyExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	private := filepath.Join(dir, "private.pem")
+	public := filepath.Join(dir, "public.pem")
+	other := filepath.Join(dir, "other.pem")
+	code, _, _ := runCLI(t, "", "keygen", "-out", private, "-pub", public)
+	require.Equal(t, exitOK, code)
+	code, _, _ = runCLI(t, "", "keygen", "-out", filepath.Join(dir, "other-private.pem"), "-pub", other)
+	require.Equal(t, exitOK, code)
+
+	create := func(args ...string) string {
+		code, token, stderr := runCLI(t, "", append([]string{"create", "-key", private, "-kid", "key-1"}, args...)...)
+		require.Equal(t, exitOK, code, stderr)
+		return strings.TrimSpace(token)
+	}
+	valid := create("-iss", "https://auth.example.com")
+
+	tests := []struct {
+		name  string
+		token string
+		args  []string
+		code  int
+	}{
+		{name: "형식 오류", token: "not-a-token", args: []string{"-key", public}, code: exitMalformed},
+		{name: "서명 오류", token: valid, args: []string{"-key", other}, code: exitSignature},
+		{name: "issuer 불일치", token: valid, args: []string{"-key", public, "-iss", "https://other.example.com"}, code: exitClaims},
+		{name: "typ 불일치", token: valid, args: []string{"-key", public, "-typ", "at+jwt"}, code: exitClaims},
+		{name: "알고리즘 불일치", token: valid, args: []string{"-key", public, "-alg", "ES384"}, code: exitUnverifiable},
+		{name: "플래그 누락", token: valid, args: nil, code: exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runCLI(t, "", append(append([]string{"verify"}, tt.args...), tt.token)...)
+			assert.Equal(t, tt.code, code, stderr)
+		})
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secret, []byte("secret\n"), 0o600))
+
+	code, token, _ := runCLI(t, "", "create", "-key", secret, "-claims", `{"exp": 1}`, "-exp", "0")
+	require.Equal(t, exitOK, code)
+
+	code, _, stderr := runCLI(t, "", "verify", "-key", secret, strings.TrimSpace(token))
+	assert.Equal(t, exitExpired, code)
+	assert.Contains(t, stderr, "expired")
+}
+
+func TestVerifyJWKS(t *testing.T) {
+	dir := t.TempDir()
+	private := filepath.Join(dir, "private.pem")
+	code, _, _ := runCLI(t, "", "keygen", "-type", "rsa", "-out", private)
+	require.Equal(t, exitOK, code)
+
+	key, _, err := loadKey(private)
+	require.NoError(t, err)
+	config, err := signingConfig(jwt.SigningMethodRS256, key, "key-1")
+	require.NoError(t, err)
+	jwks, err := v4jwt.NewJWKS(config)
+	require.NoError(t, err)
+	b, err := json.Marshal(jwks)
+	require.NoError(t, err)
+	jwksFile := filepath.Join(dir, "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, b, 0o644))
+
+	t.Run("kid 일치", func(t *testing.T) {
+		_, token, _ := runCLI(t, "", "create", "-key", private, "-kid", "key-1")
+		code, _, stderr := runCLI(t, "", "verify", "-jwks", jwksFile, strings.TrimSpace(token))
+		assert.Equal(t, exitOK, code, stderr)
+	})
+
+	t.Run("알 수 없는 kid", func(t *testing.T) {
+		_, token, _ := runCLI(t, "", "create", "-key", private, "-kid", "key-2")
+		code, _, _ := runCLI(t, "", "verify", "-jwks", jwksFile, strings.TrimSpace(token))
+		assert.Equal(t, exitUnverifiable, code)
+	})
+}
+
+func TestUnknownCommand(t *testing.T) {
+	code, _, stderr := runCLI(t, "", "sign")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, `unknown command "sign"`)
+}
+
+func TestKeygenJWK(t *testing.T) {
+	dir := t.TempDir()
+	private := filepath.Join(dir, "private.json")
+	public := filepath.Join(dir, "public.json")
+
+	code, _, stderr := runCLI(t, "", "keygen", "-type", "ed25519", "-format", "jwk", "-out", private, "-pub", public)
+	require.Equal(t, exitOK, code, stderr)
+
+	b, err := os.ReadFile(private)
+	require.NoError(t, err)
+	jwk, err := keys.ParseJWK(b)
+	require.NoError(t, err)
+	assert.Equal(t, "OKP", jwk.Kty)
+	assert.Equal(t, "EdDSA", jwk.Alg)
+	assert.True(t, jwk.IsPrivate())
+
+	b, err = os.ReadFile(public)
+	require.NoError(t, err)
+	publicJWK, err := keys.ParseJWK(b)
+	require.NoError(t, err)
+	assert.False(t, publicJWK.IsPrivate())
+	assert.Equal(t, jwk.Kid, publicJWK.Kid)
+
+	// JWK 키 파일의 kid 가 토큰 헤더에 사용되어야 함
+	code, token, stderr := runCLI(t, "", "create", "-key", private)
+	require.Equal(t, exitOK, code, stderr)
+	code, out, stderr := runCLI(t, "", "decode", strings.TrimSpace(token))
+	require.Equal(t, exitOK, code, stderr)
+	assert.Contains(t, out, `"kid": "`+jwk.Kid+`"`)
+
+	code, _, stderr = runCLI(t, "", "verify", "-key", public, strings.TrimSpace(token))
+	assert.Equal(t, exitOK, code, stderr)
+}
+
+func TestJWKConversion(t *testing.T) {
+	dir := t.TempDir()
+	private := filepath.Join(dir, "private.pem")
+	code, _, _ := runCLI(t, "", "keygen", "-type", "ec", "-curve", "P-384", "-out", private)
+	require.Equal(t, exitOK, code)
+	pemBytes, err := os.ReadFile(private)
+	require.NoError(t, err)
+
+	t.Run("PEM 을 JWK 로, 다시 PEM 으로", func(t *testing.T) {
+		code, out, stderr := runCLI(t, "", "jwk", private)
+		require.Equal(t, exitOK, code, stderr)
+		jwkFile := filepath.Join(dir, "private.json")
+		require.NoError(t, os.WriteFile(jwkFile, []byte(out), 0o600))
+
+		code, out, stderr = runCLI(t, "", "jwk", "-pem", jwkFile)
+		require.Equal(t, exitOK, code, stderr)
+		assert.Equal(t, string(pemBytes), out)
+	})
+
+	t.Run("공개 JWK Set 으로 verify", func(t *testing.T) {
+		code, out, stderr := runCLI(t, "", "jwk", "-public", "-set", private)
+		require.Equal(t, exitOK, code, stderr)
+		assert.NotContains(t, out, `"d"`)
+		jwksFile := filepath.Join(dir, "jwks.json")
+		require.NoError(t, os.WriteFile(jwksFile, []byte(out), 0o644))
+
+		var jwks v4jwt.JWKS
+		require.NoError(t, json.Unmarshal([]byte(out), &jwks))
+		require.Len(t, jwks.Keys, 1)
+
+		code, token, stderr := runCLI(t, "", "create", "-key", private, "-kid", jwks.Keys[0].Kid)
+		require.Equal(t, exitOK, code, stderr)
+		code, _, stderr = runCLI(t, "", "verify", "-jwks", jwksFile, strings.TrimSpace(token))
+		assert.Equal(t, exitOK, code, stderr)
+	})
+
+	t.Run("HMAC secret 의 공개키", func(t *testing.T) {
+		secret := filepath.Join(dir, "secret")
+		require.NoError(t, os.WriteFile(secret, []byte("secret"), 0o600))
+		code, _, _ := runCLI(t, "", "jwk", "-public", secret)
+		assert.Equal(t, exitUsage, code)
+	})
+}
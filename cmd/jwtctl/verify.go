@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/nookcoder/go-boilerplate/auth/v4jwt"
+)
+
+func runVerify(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("verify", stderr)
+	keyFile := fs.String("key", "", "public or private key (PEM or JWK) or HMAC secret file")
+	jwksFile := fs.String("jwks", "", "JWKS file; the key is selected by the kid header")
+	alg := fs.String("alg", "", "expected algorithm (default: the token's alg header)")
+	iss := fs.String("iss", "", "required issuer")
+	aud := fs.String("aud", "", "required audience")
+	var types stringList
+	fs.Var(&types, "typ", "accepted typ header (repeatable)")
+
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if (*keyFile == "") == (*jwksFile == "") {
+		fmt.Fprintln(stderr, "jwtctl verify: exactly one of -key or -jwks is required")
+		fs.Usage()
+		return exitUsage
+	}
+
+	tokenString, err := readToken(fs.Args(), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl verify: %v\n", err)
+		return exitUsage
+	}
+
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl verify: %v\n", err)
+		return exitMalformed
+	}
+
+	if *alg == "" {
+		*alg = token.Method.Alg()
+	}
+	method := jwt.GetSigningMethod(*alg)
+	if method == nil || method == jwt.SigningMethodNone {
+		fmt.Fprintf(stderr, "jwtctl verify: unsupported algorithm %q\n", *alg)
+		return exitUnverifiable
+	}
+
+	var key interface{}
+	if *keyFile != "" {
+		key, _, err = loadKey(*keyFile)
+	} else {
+		kid, _ := token.Header["kid"].(string)
+		key, err = loadJWKSKey(*jwksFile, kid)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl verify: %v\n", err)
+		if v4jwt.ErrorClass(err) == "unknown_key" {
+			return exitUnverifiable
+		}
+		return exitError
+	}
+
+	var opts []v4jwt.ValidatorOption
+	if *iss != "" {
+		opts = append(opts, v4jwt.WithIssuer(*iss))
+	}
+	if *aud != "" {
+		opts = append(opts, v4jwt.WithAudience(*aud))
+	}
+	if len(types) > 0 {
+		opts = append(opts, v4jwt.WithExpectedTypes(types...))
+	}
+
+	validator := v4jwt.NewValidator[jwt.MapClaims](verifyConfig(method, key), opts...)
+	claims, err := validator.ValidateToken(tokenString, jwt.MapClaims{})
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl verify: invalid token (%s): %v\n", v4jwt.ErrorClass(err), err)
+		return verifyExitCode(err)
+	}
+
+	b, err := json.MarshalIndent(claims, "", "  ")
+	if err != nil {
+		fmt.Fprintf(stderr, "jwtctl verify: %v\n", err)
+		return exitError
+	}
+	fmt.Fprintln(stdout, "valid")
+	fmt.Fprintln(stdout, string(b))
+	return exitOK
+}
+
+// loadJWKSKey: JWKS 파일에서 kid 에 해당하는 공개키 조회
+func loadJWKSKey(path, kid string) (interface{}, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks v4jwt.JWKS
+	if err := json.Unmarshal(b, &jwks); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// kid 가 없는 토큰은 키가 하나뿐인 JWKS 에서만 허용
+	if kid == "" && len(jwks.Keys) == 1 {
+		return jwks.Keys[0].PublicKey()
+	}
+
+	jwk, err := jwks.Key(context.Background(), kid)
+	if err != nil {
+		return nil, fmt.Errorf("kid %q: %w", kid, err)
+	}
+	return jwk.PublicKey()
+}
+
+// verifyExitCode: 검증 실패 사유별 종료 코드
+func verifyExitCode(err error) int {
+	switch v4jwt.ErrorClass(err) {
+	case "malformed":
+		return exitMalformed
+	case "signature":
+		return exitSignature
+	case "expired", "not_valid_yet":
+		return exitExpired
+	case "issuer", "audience", "claims", "type":
+		return exitClaims
+	case "unknown_key", "unverifiable":
+		return exitUnverifiable
+	default:
+		return exitError
+	}
+}